@@ -2,9 +2,11 @@ package yahw
 
 import (
 	"context"
+	"fmt"
 	"html"
 	"io"
 	"maps"
+	"slices"
 	"strings"
 )
 
@@ -26,22 +28,40 @@ func isValidAttrName(name string) bool {
 }
 
 func BuildAttr(key, value string) Attribute {
+	attr, err := TryBuildAttr(key, value)
+	if err != nil {
+		panic(err)
+	}
+	return attr
+}
+
+// TryBuildAttr is like BuildAttr, but returns an error for an invalid name.
+func TryBuildAttr(key, value string) (Attribute, error) {
 	if !isValidAttrName(key) {
-		panic("Invalid attribute name: " + key)
+		return Attribute{}, fmt.Errorf("%w: %q", ErrInvalidAttrName, key)
 	}
 	return Attribute{
 		key:   key,
 		value: value,
-	}
+	}, nil
 }
 
 func NoValAttr(key string) NoValAttribute {
+	attr, err := TryNoValAttr(key)
+	if err != nil {
+		panic(err)
+	}
+	return attr
+}
+
+// TryNoValAttr is like NoValAttr, but returns an error for an invalid name.
+func TryNoValAttr(key string) (NoValAttribute, error) {
 	if !isValidAttrName(key) {
-		panic("Invalid attribute name: " + key)
+		return NoValAttribute{}, fmt.Errorf("%w: %q", ErrInvalidAttrName, key)
 	}
 	return NoValAttribute{
 		key: key,
-	}
+	}, nil
 }
 
 func AttrBuilder(key string) func(string) Attribute {
@@ -59,18 +79,29 @@ func (a Attribute) attr()                               {}
 func (a Attribute) Node(ctx context.Context) Renderable { return a }
 
 func (a Attribute) Render(ctx context.Context, w io.Writer) error {
-	escapedKey := html.EscapeString(a.key)
-	escapedValue := html.EscapeString(a.value)
-
-	if len(escapedKey) == 0 {
+	value := a.value
+	if isURLAttr(a.key) {
+		value = sanitizeURLAttr(ctx, a.key, value)
+	}
+	if len(a.key) == 0 {
 		return nil // No attribute to render
 	}
 
-	_, err := w.Write([]byte(escapedKey + `="` + escapedValue + `"`))
-	if err != nil {
-		return err
+	return writeAttr(ctx, w, a.key, value)
+}
+
+// writeAttr writes key="value", escaping both. Minified output leaves out
+// quotes where possible and the value if it's empty.
+func writeAttr(ctx context.Context, w io.Writer, key, value string) error {
+	if minifiesMarkup(ctx) {
+		if value == "" {
+			return writeStrings(w, html.EscapeString(key))
+		}
+		if unquotable(value) {
+			return writeStrings(w, html.EscapeString(key), "=", html.EscapeString(value))
+		}
 	}
-	return nil
+	return writeStrings(w, html.EscapeString(key), `="`, html.EscapeString(value), `"`)
 }
 
 type NoValAttribute struct {
@@ -87,7 +118,10 @@ func (a NoValAttribute) Render(ctx context.Context, w io.Writer) error {
 		return nil // No attribute to render
 	}
 
-	_, err := w.Write([]byte(escapedKey))
+	if syntaxOf(ctx) == XHTMLSyntax {
+		return writeStrings(w, escapedKey, `="`, escapedKey, `"`)
+	}
+	_, err := io.WriteString(w, escapedKey)
 	if err != nil {
 		return err
 	}
@@ -102,7 +136,7 @@ func (a AttrSlice) Node(ctx context.Context) Renderable { return a }
 func (a AttrSlice) Render(ctx context.Context, w io.Writer) error {
 	for i, attr := range a {
 		if i > 0 {
-			_, err := w.Write([]byte(" "))
+			_, err := io.WriteString(w, " ")
 			if err != nil {
 				return err
 			}
@@ -126,6 +160,28 @@ func (a AttrSlice) Merge(mrg AttrSlice) AttrSlice {
 	return append(a[:], mrg...)
 }
 
+// AttrIf returns attr if cond is true and no attribute otherwise. Like any
+// attribute, it can be used with both CommonTag and SelfClosingTag.
+func AttrIf(cond bool, attr attrable) AttrSlice {
+	if !cond {
+		return AttrSlice{}
+	}
+	return AttrSlice{attr}
+}
+
+// OptionalAttr builds the attribute key with the value pointed to by value,
+// or no attribute if value is nil. It panics on an invalid name, like
+// BuildAttr.
+func OptionalAttr(key string, value *string) AttrSlice {
+	if value == nil {
+		if _, err := TryBuildAttr(key, ""); err != nil {
+			panic(err)
+		}
+		return AttrSlice{}
+	}
+	return AttrSlice{BuildAttr(key, *value)}
+}
+
 func extractClasses(cls string) []string {
 	clss := strings.Split(string(cls), " ")
 	res := make([]string, 0, len(clss))
@@ -160,11 +216,7 @@ func (c Classes) Node(ctx context.Context) Renderable { return c }
 
 func (c Classes) Render(ctx context.Context, w io.Writer) error {
 	res := extractClasses(string(c))
-	_, err := w.Write([]byte(`class="` + strings.Join(res, " ") + `"`))
-	if err != nil {
-		return err
-	}
-	return nil
+	return writeAttr(ctx, w, "class", strings.Join(res, " "))
 }
 
 func (c Classes) Add(s string) Classes {
@@ -175,17 +227,24 @@ func (c Classes) Merge(oth Classes) Classes {
 	return c.Add(string(oth))
 }
 
+// MergeMap keeps the classes of c that aren't disabled in m, followed by the
+// remaining classes enabled in m in sorted order.
 func (c Classes) MergeMap(m ClassesMap) Classes {
-	cmap := ClassesMap{}
+	res := []string{}
 	for _, cls := range extractClasses(string(c)) {
-		cmap[cls] = true
+		if enabled, ok := m[cls]; ok && !enabled {
+			continue
+		}
+		res = append(res, cls)
 	}
 
-	for cls, ok := range m {
-		cmap[cls] = ok
+	for _, cls := range m.enabled() {
+		if !slices.Contains(res, cls) {
+			res = append(res, cls)
+		}
 	}
 
-	return Classes(cmap.extract())
+	return Classes(strings.Join(res, " "))
 }
 
 type ClassesMap map[string]bool
@@ -193,31 +252,26 @@ type ClassesMap map[string]bool
 func (c ClassesMap) attr()                               {}
 func (c ClassesMap) Node(ctx context.Context) Renderable { return c }
 
-func (c ClassesMap) extract() string {
-	var sb strings.Builder
-	cnt := len(c)
-	ind := -1
+// enabled returns the enabled classes, sorted so the output doesn't depend on
+// map iteration order.
+func (c ClassesMap) enabled() []string {
+	res := make([]string, 0, len(c))
 	for k, v := range c {
-		ind++
-		if !v {
-			continue
+		if v {
+			res = append(res, k)
 		}
-		sb.WriteString(k)
-		if ind < cnt-1 {
-			sb.WriteString(" ")
-		}
-
 	}
-	return sb.String()
+	slices.Sort(res)
+	return res
+}
+
+func (c ClassesMap) extract() string {
+	return strings.Join(c.enabled(), " ")
 }
 
 func (c ClassesMap) Render(ctx context.Context, w io.Writer) error {
 	s := c.extract()
-	_, err := w.Write([]byte(`class="` + s + `"`))
-	if err != nil {
-		return err
-	}
-	return nil
+	return writeAttr(ctx, w, "class", s)
 }
 
 func (c ClassesMap) Add(s string) ClassesMap {
@@ -234,26 +288,26 @@ func (c ClassesMap) Add(s string) ClassesMap {
 
 func ID(id string) Attribute { return BuildAttr("id", id) }
 
-func OnClick(handler string) Attribute     { return BuildAttr("onclick", handler) }
-func OnChange(handler string) Attribute    { return BuildAttr("onchange", handler) }
-func OnMouseOver(handler string) Attribute { return BuildAttr("onmouseover", handler) }
-func OnMouseOut(handler string) Attribute  { return BuildAttr("onmouseout", handler) }
-func OnMouseDown(handler string) Attribute { return BuildAttr("onmousedown", handler) }
-func OnMouseUp(handler string) Attribute   { return BuildAttr("onmouseup", handler) }
-func OnFocus(handler string) Attribute     { return BuildAttr("onfocus", handler) }
-func OnBlur(handler string) Attribute      { return BuildAttr("onblur", handler) }
-func OnKeyDown(handler string) Attribute   { return BuildAttr("onkeydown", handler) }
-func OnKeyPress(handler string) Attribute  { return BuildAttr("onkeypress", handler) }
-func OnKeyUp(handler string) Attribute     { return BuildAttr("onkeyup", handler) }
-func OnLoad(handler string) Attribute      { return BuildAttr("onload", handler) }
-func OnSubmit(handler string) Attribute    { return BuildAttr("onsubmit", handler) }
-func OnReset(handler string) Attribute     { return BuildAttr("onreset", handler) }
-func OnSelect(handler string) Attribute    { return BuildAttr("onselect", handler) }
-func OnAbort(handler string) Attribute     { return BuildAttr("onabort", handler) }
-func OnError(handler string) Attribute     { return BuildAttr("onerror", handler) }
-func OnResize(handler string) Attribute    { return BuildAttr("onresize", handler) }
-func OnScroll(handler string) Attribute    { return BuildAttr("onscroll", handler) }
-func OnUnload(handler string) Attribute    { return BuildAttr("onunload", handler) }
+func OnClick[T jsHandler](handler T) Attribute     { return eventAttr("onclick", handler) }
+func OnChange[T jsHandler](handler T) Attribute    { return eventAttr("onchange", handler) }
+func OnMouseOver[T jsHandler](handler T) Attribute { return eventAttr("onmouseover", handler) }
+func OnMouseOut[T jsHandler](handler T) Attribute  { return eventAttr("onmouseout", handler) }
+func OnMouseDown[T jsHandler](handler T) Attribute { return eventAttr("onmousedown", handler) }
+func OnMouseUp[T jsHandler](handler T) Attribute   { return eventAttr("onmouseup", handler) }
+func OnFocus[T jsHandler](handler T) Attribute     { return eventAttr("onfocus", handler) }
+func OnBlur[T jsHandler](handler T) Attribute      { return eventAttr("onblur", handler) }
+func OnKeyDown[T jsHandler](handler T) Attribute   { return eventAttr("onkeydown", handler) }
+func OnKeyPress[T jsHandler](handler T) Attribute  { return eventAttr("onkeypress", handler) }
+func OnKeyUp[T jsHandler](handler T) Attribute     { return eventAttr("onkeyup", handler) }
+func OnLoad[T jsHandler](handler T) Attribute      { return eventAttr("onload", handler) }
+func OnSubmit[T jsHandler](handler T) Attribute    { return eventAttr("onsubmit", handler) }
+func OnReset[T jsHandler](handler T) Attribute     { return eventAttr("onreset", handler) }
+func OnSelect[T jsHandler](handler T) Attribute    { return eventAttr("onselect", handler) }
+func OnAbort[T jsHandler](handler T) Attribute     { return eventAttr("onabort", handler) }
+func OnError[T jsHandler](handler T) Attribute     { return eventAttr("onerror", handler) }
+func OnResize[T jsHandler](handler T) Attribute    { return eventAttr("onresize", handler) }
+func OnScroll[T jsHandler](handler T) Attribute    { return eventAttr("onscroll", handler) }
+func OnUnload[T jsHandler](handler T) Attribute    { return eventAttr("onunload", handler) }
 
 // Global attributes
 
@@ -278,61 +332,65 @@ func AutoSave(autoSave string) Attribute         { return BuildAttr("autosave",
 func FormAttr(form string) Attribute             { return BuildAttr("form", form) }
 func EncType(encType string) Attribute           { return BuildAttr("enctype", encType) }
 func Accept(accept string) Attribute             { return BuildAttr("accept", accept) }
-func FormAction(formAction string) Attribute     { return BuildAttr("formaction", formAction) }
-func FormEncType(formEncType string) Attribute   { return BuildAttr("formenctype", formEncType) }
-func FormMethod(formMethod string) Attribute     { return BuildAttr("formmethod", formMethod) }
+func FormAction[T string | TrustedURL](formAction T) URLAttribute {
+	return BuildURLAttr("formaction", formAction)
+}
+func FormEncType(formEncType string) Attribute { return BuildAttr("formenctype", formEncType) }
+func FormMethod(formMethod string) Attribute   { return BuildAttr("formmethod", formMethod) }
 func FormNoValidate(formNoValidate string) Attribute {
 	return BuildAttr("formnovalidate", formNoValidate)
 }
-func FormTarget(formTarget string) Attribute   { return BuildAttr("formtarget", formTarget) }
-func List(list string) Attribute               { return BuildAttr("list", list) }
-func Max(max string) Attribute                 { return BuildAttr("max", max) }
-func Min(min string) Attribute                 { return BuildAttr("min", min) }
-func Multiple(multiple string) Attribute       { return BuildAttr("multiple", multiple) }
-func Pattern(pattern string) Attribute         { return BuildAttr("pattern", pattern) }
-func Placeholder(placeholder string) Attribute { return BuildAttr("placeholder", placeholder) }
-func ReadOnly() NoValAttribute                 { return NoValAttr("readonly") }
-func Required() NoValAttribute                 { return NoValAttr("required") }
-func Size(size string) Attribute               { return BuildAttr("size", size) }
-func Src(src string) Attribute                 { return BuildAttr("src", src) }
-func Step(step string) Attribute               { return BuildAttr("step", step) }
-func Width(width string) Attribute             { return BuildAttr("width", width) }
-func Height(height string) Attribute           { return BuildAttr("height", height) }
-func Alt(alt string) Attribute                 { return BuildAttr("alt", alt) }
-func UseMap(useMap string) Attribute           { return BuildAttr("usemap", useMap) }
-func IsMap(isMap string) Attribute             { return BuildAttr("ismap", isMap) }
-func LongDesc(longDesc string) Attribute       { return BuildAttr("longdesc", longDesc) }
-func SrcSet(srcSet string) Attribute           { return BuildAttr("srcset", srcSet) }
-func Sizes(sizes string) Attribute             { return BuildAttr("sizes", sizes) }
-func CrossOrigin(crossOrigin string) Attribute { return BuildAttr("crossorigin", crossOrigin) }
-func Media(media string) Attribute             { return BuildAttr("media", media) }
-func Type(type_ string) Attribute              { return BuildAttr("type", type_) }
-func Charset(charset string) Attribute         { return BuildAttr("charset", charset) }
-func Href(href string) Attribute               { return BuildAttr("href", href) }
-func HrefLang(hrefLang string) Attribute       { return BuildAttr("hreflang", hrefLang) }
-func Rel(rel string) Attribute                 { return BuildAttr("rel", rel) }
-func Rev(rev string) Attribute                 { return BuildAttr("rev", rev) }
-func Target(target string) Attribute           { return BuildAttr("target", target) }
-func Download(download string) Attribute       { return BuildAttr("download", download) }
-func Ping(ping string) Attribute               { return BuildAttr("ping", ping) }
+func FormTarget(formTarget string) Attribute        { return BuildAttr("formtarget", formTarget) }
+func List(list string) Attribute                    { return BuildAttr("list", list) }
+func Max(max string) Attribute                      { return BuildAttr("max", max) }
+func Min(min string) Attribute                      { return BuildAttr("min", min) }
+func Multiple(multiple string) Attribute            { return BuildAttr("multiple", multiple) }
+func Pattern(pattern string) Attribute              { return BuildAttr("pattern", pattern) }
+func Placeholder(placeholder string) Attribute      { return BuildAttr("placeholder", placeholder) }
+func ReadOnly() NoValAttribute                      { return NoValAttr("readonly") }
+func Required() NoValAttribute                      { return NoValAttr("required") }
+func Size(size string) Attribute                    { return BuildAttr("size", size) }
+func Src[T string | TrustedURL](src T) URLAttribute { return BuildURLAttr("src", src) }
+func Step(step string) Attribute                    { return BuildAttr("step", step) }
+func Width(width string) Attribute                  { return BuildAttr("width", width) }
+func Height(height string) Attribute                { return BuildAttr("height", height) }
+func Alt(alt string) Attribute                      { return BuildAttr("alt", alt) }
+func UseMap(useMap string) Attribute                { return BuildAttr("usemap", useMap) }
+func IsMap(isMap string) Attribute                  { return BuildAttr("ismap", isMap) }
+func LongDesc[T string | TrustedURL](longDesc T) URLAttribute {
+	return BuildURLAttr("longdesc", longDesc)
+}
+func SrcSet[T string | TrustedURL](srcSet T) URLAttribute { return BuildURLAttr("srcset", srcSet) }
+func Sizes(sizes string) Attribute                        { return BuildAttr("sizes", sizes) }
+func CrossOrigin(crossOrigin string) Attribute            { return BuildAttr("crossorigin", crossOrigin) }
+func Media(media string) Attribute                        { return BuildAttr("media", media) }
+func Type(type_ string) Attribute                         { return BuildAttr("type", type_) }
+func Charset(charset string) Attribute                    { return BuildAttr("charset", charset) }
+func Href[T string | TrustedURL](href T) URLAttribute     { return BuildURLAttr("href", href) }
+func HrefLang(hrefLang string) Attribute                  { return BuildAttr("hreflang", hrefLang) }
+func Rel(rel string) Attribute                            { return BuildAttr("rel", rel) }
+func Rev(rev string) Attribute                            { return BuildAttr("rev", rev) }
+func Target(target string) Attribute                      { return BuildAttr("target", target) }
+func Download(download string) Attribute                  { return BuildAttr("download", download) }
+func Ping[T string | TrustedURL](ping T) URLAttribute     { return BuildURLAttr("ping", ping) }
 func ReferrerPolicy(referrerPolicy string) Attribute {
 	return BuildAttr("referrerpolicy", referrerPolicy)
 }
-func Integrity(integrity string) Attribute { return BuildAttr("integrity", integrity) }
-func Content(content string) Attribute     { return BuildAttr("content", content) }
-func HttpEquiv(httpEquiv string) Attribute { return BuildAttr("http-equiv", httpEquiv) }
-func Name(name string) Attribute           { return BuildAttr("name", name) }
-func Scheme(scheme string) Attribute       { return BuildAttr("scheme", scheme) }
-func Coords(coords string) Attribute       { return BuildAttr("coords", coords) }
-func Shape(shape string) Attribute         { return BuildAttr("shape", shape) }
-func Axis(axis string) Attribute           { return BuildAttr("axis", axis) }
-func Headers(headers string) Attribute     { return BuildAttr("headers", headers) }
-func Scope(scope string) Attribute         { return BuildAttr("scope", scope) }
-func ColSpan(colSpan string) Attribute     { return BuildAttr("colspan", colSpan) }
-func RowSpan(rowSpan string) Attribute     { return BuildAttr("rowspan", rowSpan) }
-func Action(action string) Attribute       { return BuildAttr("action", action) }
-func Method(method string) Attribute       { return BuildAttr("method", method) }
-func NoValidate() NoValAttribute           { return NoValAttr("novalidate") }
+func Integrity(integrity string) Attribute                { return BuildAttr("integrity", integrity) }
+func Content(content string) Attribute                    { return BuildAttr("content", content) }
+func HttpEquiv(httpEquiv string) Attribute                { return BuildAttr("http-equiv", httpEquiv) }
+func Name(name string) Attribute                          { return BuildAttr("name", name) }
+func Scheme(scheme string) Attribute                      { return BuildAttr("scheme", scheme) }
+func Coords(coords string) Attribute                      { return BuildAttr("coords", coords) }
+func Shape(shape string) Attribute                        { return BuildAttr("shape", shape) }
+func Axis(axis string) Attribute                          { return BuildAttr("axis", axis) }
+func Headers(headers string) Attribute                    { return BuildAttr("headers", headers) }
+func Scope(scope string) Attribute                        { return BuildAttr("scope", scope) }
+func ColSpan(colSpan string) Attribute                    { return BuildAttr("colspan", colSpan) }
+func RowSpan(rowSpan string) Attribute                    { return BuildAttr("rowspan", rowSpan) }
+func Action[T string | TrustedURL](action T) URLAttribute { return BuildURLAttr("action", action) }
+func Method(method string) Attribute                      { return BuildAttr("method", method) }
+func NoValidate() NoValAttribute                          { return NoValAttr("novalidate") }
 
 // Additional common attributes
 func TitleAttr(title string) Attribute             { return BuildAttr("title", title) }
@@ -1,6 +1,7 @@
 package yahw
 
 import (
+	"context"
 	"strings"
 	"testing"
 )
@@ -10,7 +11,7 @@ func assertEqual(t *testing.T, r any, expected string) {
 	var err error
 	switch r := r.(type) {
 	case Renderable:
-		err = r.Render(strbuf)
+		err = r.Render(context.Background(), strbuf)
 	default:
 		t.Errorf("Unknown type: %T", r)
 	}
@@ -111,3 +112,73 @@ func TestClassNames(t *testing.T) {
 		})
 	}
 }
+
+func TestClassesMap(t *testing.T) {
+	tt := []struct {
+		Name   string
+		Attr   Renderable
+		Expect string
+	}{
+		{Name: "Sorted", Attr: ClassesMap{"c": true, "a": true, "b": true}, Expect: `class="a b c"`},
+		{Name: "Disabled last", Attr: ClassesMap{"a": true, "z": false}, Expect: `class="a"`},
+		{Name: "Disabled first", Attr: ClassesMap{"a": false, "z": true}, Expect: `class="z"`},
+		{Name: "Merged with classes", Attr: Classes("z y x").MergeMap(ClassesMap{"y": false, "b": true, "a": true}), Expect: `class="z x a b"`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Attr, tc.Expect)
+		})
+	}
+}
+
+func TestMergingClassAttributes(t *testing.T) {
+	div := TagBuilder("div")
+	img := SelfClosingTagBuilder("img")
+
+	tt := []struct {
+		Name   string
+		Tag    Renderable
+		Expect string
+	}{
+		{Name: "Merged at first position", Tag: div(ID("a"), Classes("x"), TitleAttr("t"), ClassesMap{"y": true}, Class("z")), Expect: `<div id="a" class="x y z" title="t"></div>`},
+		{Name: "Only classes", Tag: div(Classes("x"), Classes("y")), Expect: `<div class="x y"></div>`},
+		{Name: "Nested attribute slices", Tag: div(AttrSlice{ID("a"), Classes("x")}, Classes("y")), Expect: `<div id="a" class="x y"></div>`},
+		{Name: "Self-closing tag", Tag: img(Classes("x"), Src("a.png"), ClassesMap{"y": true, "b": true}), Expect: `<img class="x b y" src="a.png" />`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Tag, tc.Expect)
+		})
+	}
+}
+
+func TestDeterministicRendering(t *testing.T) {
+	classes := ClassesMap{}
+	for _, c := range strings.Fields("a b c d e f g h i j k l m n o p q r s t u v w x y z") {
+		classes[c] = c != "m"
+	}
+	tree := TagBuilder("div")(
+		classes,
+		ID("root"),
+		Classes("first"),
+		TagBuilder("span")(ClassesMap{"x": true, "y": true, "z": true}, DataAttr("k", "v"), classes),
+		SelfClosingTagBuilder("input")(classes, Name("n"), Classes("last").MergeMap(classes)),
+	)
+
+	render := func() string {
+		strbuf := &strings.Builder{}
+		if err := tree.Render(context.Background(), strbuf); err != nil {
+			t.Fatalf("Error rendering: %s", err)
+		}
+		return strbuf.String()
+	}
+
+	expected := render()
+	for i := 0; i < 100; i++ {
+		if got := render(); got != expected {
+			t.Fatalf("Render %d differs.\nExpected %s\ngot      %s", i, expected, got)
+		}
+	}
+}
@@ -1,13 +1,187 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
 
 	"github.com/vizualni/yahw/parsehtml"
 )
 
+const usage = `Usage: yahw convert [flags] [file.html]
+       yahw convert -dir dir [flags]
+
+Converts HTML into Go code building it with yahw. Without a file, HTML is read
+from stdin. With -dir, every .html file in dir is converted into a .go file
+with the same name.
+
+Flags:
+`
+
 func main() {
-	code := parsehtml.GenerateGo(os.Stdin)
-	fmt.Println(code)
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "convert" {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("convert", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+	pkg := fs.String("pkg", "views", "package name of the generated code")
+	fn := fs.String("func", "", "name of the generated function (default derived from the file name)")
+	out := fs.String("o", "", "output file, or output directory with -dir (default stdout, or dir)")
+	dir := fs.String("dir", "", "convert every .html file in this directory")
+	doc := fs.Bool("doc", false, "parse input as a whole document instead of a fragment")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	if *dir != "" {
+		if *fn != "" || fs.NArg() > 0 {
+			fmt.Fprintln(stderr, "yahw: -dir can't be used with -func or an input file")
+			return 2
+		}
+		outDir := *out
+		if outDir == "" {
+			outDir = *dir
+		}
+		return convertDir(*dir, outDir, *pkg, *doc, stderr)
+	}
+
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return 2
+	}
+
+	name := "<stdin>"
+	in := stdin
+	if fs.NArg() == 1 {
+		name = fs.Arg(0)
+		f, err := os.Open(name)
+		if err != nil {
+			fmt.Fprintf(stderr, "yahw: %s\n", err)
+			return 1
+		}
+		defer f.Close()
+		in = f
+	}
+
+	cfg := parsehtml.FileConfig{Package: *pkg, Func: *fn, Document: *doc}
+	if cfg.Func == "" {
+		cfg.Func = "Page"
+		if in != stdin {
+			cfg.Func = funcName(name)
+		}
+	}
+	code, err := parsehtml.GenerateFile(in, cfg)
+	if err != nil {
+		printError(stderr, name, err)
+		return 1
+	}
+
+	if *out == "" {
+		fmt.Fprint(stdout, code)
+		return 0
+	}
+	if err := os.WriteFile(*out, []byte(code), 0o644); err != nil {
+		fmt.Fprintf(stderr, "yahw: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// convertDir converts every .html file in dir. It keeps going after a file
+// fails, so all errors are reported at once. Nothing is converted if two files
+// would generate functions with the same name.
+func convertDir(dir, outDir, pkg string, doc bool, stderr io.Writer) int {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.html"))
+	if err != nil {
+		fmt.Fprintf(stderr, "yahw: %s\n", err)
+		return 1
+	}
+
+	// Every file becomes a function in the same package, so two files whose
+	// names turn into the same function name would make it fail to compile.
+	funcs := map[string]string{}
+	for _, path := range paths {
+		name := funcName(path)
+		if other, ok := funcs[name]; ok {
+			fmt.Fprintf(stderr, "yahw: %s and %s both generate func %s\n", other, path, name)
+			return 1
+		}
+		funcs[name] = path
+	}
+
+	code := 0
+	for _, path := range paths {
+		if err := convertFile(path, outDir, pkg, doc); err != nil {
+			printError(stderr, path, err)
+			code = 1
+		}
+	}
+	return code
+}
+
+func convertFile(path, outDir, pkg string, doc bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	code, err := parsehtml.GenerateFile(f, parsehtml.FileConfig{Package: pkg, Func: funcName(path), Document: doc})
+	if err != nil {
+		return err
+	}
+
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return os.WriteFile(filepath.Join(outDir, base+".go"), []byte(code), 0o644)
+}
+
+// printError prints err prefixed with the input it's about, so syntax errors
+// read as file.html:line:column: message.
+func printError(w io.Writer, name string, err error) {
+	var syntaxErr *parsehtml.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		fmt.Fprintf(w, "%s:%s\n", name, err)
+		return
+	}
+	fmt.Fprintf(w, "%s: %s\n", name, err)
+}
+
+// funcName turns a file name like "user-profile.html" into "UserProfile".
+func funcName(path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	var sb strings.Builder
+	upper := true
+	for _, r := range base {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if sb.Len() == 0 && unicode.IsDigit(r) {
+			sb.WriteString("Page")
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	if sb.Len() == 0 {
+		return "Page"
+	}
+	return sb.String()
 }
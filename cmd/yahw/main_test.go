@@ -0,0 +1,165 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runConvert(t *testing.T, stdin string, args ...string) (int, string, string) {
+	t.Helper()
+	stdout, stderr := &strings.Builder{}, &strings.Builder{}
+	code := run(args, strings.NewReader(stdin), stdout, stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	tt := []struct {
+		Name string
+		Args []string
+	}{
+		{Name: "No command", Args: nil},
+		{Name: "Unknown command", Args: []string{"build"}},
+		{Name: "Unknown flag", Args: []string{"convert", "-x"}},
+		{Name: "Two files", Args: []string{"convert", "a.html", "b.html"}},
+		{Name: "Dir with func", Args: []string{"convert", "-dir", ".", "-func", "F"}},
+		{Name: "Dir with file", Args: []string{"convert", "-dir", ".", "a.html"}},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			code, stdout, stderr := runConvert(t, "", tc.Args...)
+			if code != 2 {
+				t.Errorf("Expected exit code 2, got %d", code)
+			}
+			if stdout != "" || stderr == "" {
+				t.Errorf("Expected usage on stderr only, got stdout %q, stderr %q", stdout, stderr)
+			}
+		})
+	}
+}
+
+func TestConvertStdin(t *testing.T) {
+	code, stdout, stderr := runConvert(t, `<p class="a">Hi</p>`, "convert", "-pkg", "pages", "-func", "Hello")
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr)
+	}
+	for _, exp := range []string{"package pages", "func Hello(", `Classes("a")`, `Text("Hi")`} {
+		if !strings.Contains(stdout, exp) {
+			t.Errorf("Expected %s in\n%s", exp, stdout)
+		}
+	}
+}
+
+func TestConvertErrors(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bad.html")
+	writeFile(t, path, "<div>\n  <p a\"b=\"c\">x</p></div>")
+
+	code, stdout, stderr := runConvert(t, "", "convert", path)
+	if code != 1 {
+		t.Errorf("Expected exit code 1, got %d", code)
+	}
+	if stdout != "" {
+		t.Errorf("Expected no output, got %s", stdout)
+	}
+	if exp := path + ":2:3: invalid attribute name"; !strings.HasPrefix(stderr, exp) {
+		t.Errorf("Expected %s, got %s", exp, stderr)
+	}
+
+	code, _, stderr = runConvert(t, "", "convert", filepath.Join(dir, "missing.html"))
+	if code != 1 || stderr == "" {
+		t.Errorf("Expected exit code 1 and an error for a missing file, got %d, %q", code, stderr)
+	}
+}
+
+func TestConvertOutputFile(t *testing.T) {
+	dir := t.TempDir()
+	in, out := filepath.Join(dir, "user-profile.html"), filepath.Join(dir, "out.go")
+	writeFile(t, in, "<p>x</p>")
+
+	code, stdout, stderr := runConvert(t, "", "convert", "-o", out, in)
+	if code != 0 {
+		t.Fatalf("Expected exit code 0, got %d: %s", code, stderr)
+	}
+	if stdout != "" {
+		t.Errorf("Expected nothing on stdout, got %s", stdout)
+	}
+	bz, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(bz), "func UserProfile(") {
+		t.Errorf("Expected func UserProfile in\n%s", bz)
+	}
+}
+
+func TestConvertDir(t *testing.T) {
+	dir, outDir := t.TempDir(), t.TempDir()
+	writeFile(t, filepath.Join(dir, "home.html"), "<p>x</p>")
+	writeFile(t, filepath.Join(dir, "user-list.html"), "<ul><li>x</li></ul>")
+	writeFile(t, filepath.Join(dir, "bad.html"), "<p>\n<a:b></a:b></p>")
+	writeFile(t, filepath.Join(dir, "notes.txt"), "<p>x</p>")
+
+	code, _, stderr := runConvert(t, "", "convert", "-dir", dir, "-o", outDir, "-pkg", "pages")
+	if code != 1 {
+		t.Errorf("Expected exit code 1, got %d", code)
+	}
+	if exp := filepath.Join(dir, "bad.html") + ":2:1: invalid tag name"; !strings.HasPrefix(stderr, exp) {
+		t.Errorf("Expected %s, got %s", exp, stderr)
+	}
+
+	for file, fn := range map[string]string{"home.go": "Home", "user-list.go": "UserList"} {
+		bz, err := os.ReadFile(filepath.Join(outDir, file))
+		if err != nil {
+			t.Errorf("Expected %s to be written: %s", file, err)
+			continue
+		}
+		if !strings.Contains(string(bz), "package pages") || !strings.Contains(string(bz), "func "+fn+"(") {
+			t.Errorf("Expected package pages and func %s in\n%s", fn, bz)
+		}
+	}
+	entries, _ := os.ReadDir(outDir)
+	if len(entries) != 2 {
+		t.Errorf("Expected 2 files, got %d", len(entries))
+	}
+}
+
+func TestConvertDirFuncNameCollision(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "a-b.html"), "<p>x</p>")
+	writeFile(t, filepath.Join(dir, "a_b.html"), "<p>y</p>")
+
+	code, _, stderr := runConvert(t, "", "convert", "-dir", dir)
+	if code != 1 {
+		t.Errorf("Expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(stderr, "func AB") {
+		t.Errorf("Expected the collision to be reported, got %s", stderr)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, "*.go")); len(matches) != 0 {
+		t.Errorf("Expected nothing to be written, got %v", matches)
+	}
+}
+
+func TestFuncName(t *testing.T) {
+	for in, exp := range map[string]string{
+		"index.html":           "Index",
+		"dir/user-profile.htm": "UserProfile",
+		"a_b.html":             "AB",
+		"404.html":             "Page404",
+		"---.html":             "Page",
+	} {
+		if got := funcName(in); got != exp {
+			t.Errorf("%s: expected %s, got %s", in, exp, got)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package yahw
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Comment is rendered as an HTML comment. Anything in it that would end the
+// comment early is broken up, so it's safe to use with any string.
+type Comment string
+
+var (
+	_ Node     = Comment("")
+	_ taggable = Comment("")
+)
+
+func (c Comment) tag()                                {}
+func (c Comment) Node(ctx context.Context) Renderable { return c }
+
+func (c Comment) Render(ctx context.Context, w io.Writer) error {
+	return writeStrings(w, "<!--", escapeComment(string(c)), "-->")
+}
+
+// escapeComment makes s valid comment text in both HTML and XML: it can't
+// start with ">" or "->", contain "--" or end with "-".
+func escapeComment(s string) string {
+	for strings.Contains(s, "--") {
+		s = strings.ReplaceAll(s, "--", "- -")
+	}
+	if strings.HasPrefix(s, ">") || strings.HasPrefix(s, "->") {
+		s = " " + s
+	}
+	if strings.HasSuffix(s, "-") {
+		s += " "
+	}
+	return s
+}
+
+// CDATA is rendered as a CDATA section, whose content isn't escaped. It's
+// only parsed as such in XHTML and within SVG and MathML. Elsewhere, HTML
+// parsers read it as a comment ending at the first ">", so outside XHTML its
+// content can't contain ">", except within script and style. A "]]>" in it is
+// split across two sections.
+type CDATA string
+
+var (
+	_ Node     = CDATA("")
+	_ taggable = CDATA("")
+)
+
+func (c CDATA) tag()                                {}
+func (c CDATA) Node(ctx context.Context) Renderable { return c }
+
+func (c CDATA) Render(ctx context.Context, w io.Writer) error {
+	if err := c.validate(ctx); err != nil {
+		return err
+	}
+	s := strings.ReplaceAll(string(c), "]]>", "]]]]><![CDATA[>")
+	if tagName := parentTag(ctx); kindOf(tagName) == rawTextElement && syntaxOf(ctx) != XHTMLSyntax {
+		s = escapeRawText(tagName, s)
+	}
+	return writeStrings(w, "<![CDATA[", s, "]]>")
+}
+
+func (c CDATA) validate(ctx context.Context) error {
+	if kindOf(parentTag(ctx)) == rawTextElement {
+		return nil
+	}
+	return checkBogusComment(ctx, "CDATA", string(c))
+}
+
+type procInst struct {
+	target, data string
+}
+
+func (p procInst) tag()                                {}
+func (p procInst) Node(ctx context.Context) Renderable { return p }
+
+func (p procInst) Render(ctx context.Context, w io.Writer) error {
+	if err := p.validate(ctx); err != nil {
+		return err
+	}
+	if p.data == "" {
+		return writeStrings(w, "<?", p.target, "?>")
+	}
+	return writeStrings(w, "<?", p.target, " ", p.data, "?>")
+}
+
+func (p procInst) validate(ctx context.Context) error {
+	if !isValidPITarget(p.target) {
+		return fmt.Errorf("%w: processing instruction target %q", ErrInvalidNode, p.target)
+	}
+	if strings.Contains(p.data, "?>") {
+		return fmt.Errorf("%w: processing instruction data can't contain \"?>\"", ErrInvalidNode)
+	}
+	return checkBogusComment(ctx, "processing instruction data", p.data)
+}
+
+// checkBogusComment checks the content s of a CDATA section or processing
+// instruction. Outside XHTML, HTML parsers read both as a comment ending at
+// the first ">", and within elements like script and textarea as text, where
+// a "<" could start their end tag.
+func checkBogusComment(ctx context.Context, what, s string) error {
+	if syntaxOf(ctx) == XHTMLSyntax {
+		return nil
+	}
+	if strings.Contains(s, ">") {
+		return fmt.Errorf("%w: %s can't contain \">\" outside XHTML", ErrInvalidNode, what)
+	}
+	if tagName := parentTag(ctx); kindOf(tagName) != normalElement && strings.Contains(s, "<") {
+		return fmt.Errorf("%w: %s can't contain \"<\" within %s outside XHTML", ErrInvalidNode, what, tagName)
+	}
+	return nil
+}
+
+// ProcessingInstruction renders <?target data?>, e.g. an xml-stylesheet
+// instruction for XHTML. HTML parsers read it as a comment ending at the
+// first ">", so outside XHTML, data can't contain ">".
+func ProcessingInstruction(target, data string) Node {
+	return procInst{target: target, data: data}
+}
+
+// isValidPITarget reports whether target is an XML name without a colon.
+func isValidPITarget(target string) bool {
+	if target == "" {
+		return false
+	}
+	for i, r := range target {
+		switch {
+		case r == '_', unicode.IsLetter(r):
+		case i > 0 && (r == '-' || r == '.' || unicode.IsDigit(r)):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+type conditionalComment struct {
+	cond     string
+	revealed bool
+	children Fragment
+}
+
+func (c conditionalComment) tag()                                {}
+func (c conditionalComment) Node(ctx context.Context) Renderable { return c }
+
+func (c conditionalComment) Render(ctx context.Context, w io.Writer) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	// The children are followed by the closing comment, not by what follows
+	// the conditional comment.
+	if m, ok := minifyOf(ctx); ok {
+		ctx = withMinify(ctx, m.followed())
+	}
+	if c.revealed {
+		if err := writeStrings(w, "<!--[if ", c.cond, "]><!-->"); err != nil {
+			return err
+		}
+		if err := c.children.Render(ctx, w); err != nil {
+			return err
+		}
+		return writeStrings(w, "<!--<![endif]-->")
+	}
+
+	// Everything up to the first "-->" is within the comment for browsers
+	// ignoring the condition, so the content has to be checked before it's
+	// written.
+	content, err := c.content(ctx)
+	if err != nil {
+		return err
+	}
+	return writeStrings(w, "<!--[if ", c.cond, "]>", content, "<![endif]-->")
+}
+
+func (c conditionalComment) validate() error {
+	if !isValidCondition(c.cond) {
+		return fmt.Errorf("%w: conditional comment condition %q", ErrInvalidNode, c.cond)
+	}
+	return nil
+}
+
+// content renders the children of a conditional comment that isn't revealed
+// and checks that they don't end the comment early.
+func (c conditionalComment) content(ctx context.Context) (string, error) {
+	sb := &strings.Builder{}
+	if err := c.children.Render(ctx, sb); err != nil {
+		return "", err
+	}
+	if strings.Contains(sb.String(), "-->") {
+		return "", fmt.Errorf("%w: conditional comment content can't contain \"-->\"", ErrInvalidNode)
+	}
+	return sb.String(), nil
+}
+
+// validateNode reports what makes the CDATA section, processing instruction
+// or conditional comment r invalid, if it's one. Other nodes are checked when
+// they're rendered.
+func validateNode(ctx context.Context, r Renderable) error {
+	switch t := r.(type) {
+	case CDATA:
+		return t.validate(ctx)
+	case procInst:
+		return t.validate(ctx)
+	case conditionalComment:
+		return t.validate()
+	}
+	return nil
+}
+
+// IfComment renders nodes within a conditional comment, so only clients
+// evaluating cond as true see them, e.g. IfComment("mso", ...) for Outlook.
+// Comments can't be nested, so nodes can't contain a Comment.
+func IfComment(cond string, nodes ...Node) Node {
+	return conditionalComment{cond: cond, children: nodes}
+}
+
+// RevealedIfComment is like IfComment, but clients that don't support
+// conditional comments see nodes too, e.g. RevealedIfComment("!mso", ...)
+// only hides them from Outlook.
+func RevealedIfComment(cond string, nodes ...Node) Node {
+	return conditionalComment{cond: cond, revealed: true, children: nodes}
+}
+
+// isValidCondition reports whether cond only uses the syntax of conditional
+// comments, e.g. "gte mso 9" or "(IE 8)|(IE 9)".
+func isValidCondition(cond string) bool {
+	if strings.TrimSpace(cond) == "" {
+		return false
+	}
+	for _, r := range cond {
+		switch {
+		case r < utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+		case strings.ContainsRune(" !()&|.", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,226 @@
+package yahw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestComment(t *testing.T) {
+	tt := []struct {
+		Name    string
+		Comment Comment
+		Exp     string
+	}{
+		{Name: "Simple", Comment: Comment(" hello "), Exp: "<!-- hello -->"},
+		{Name: "Empty", Comment: Comment(""), Exp: "<!---->"},
+		{Name: "Markup is kept", Comment: Comment("<b>&</b>"), Exp: "<!--<b>&</b>-->"},
+		{Name: "Terminator", Comment: Comment("a --> <script>"), Exp: "<!--a - -> <script>-->"},
+		{Name: "Bang terminator", Comment: Comment("a --!> b"), Exp: "<!--a - -!> b-->"},
+		{Name: "Nested opening", Comment: Comment("<!-- a"), Exp: "<!--<!- - a-->"},
+		{Name: "Many dashes", Comment: Comment("a----b"), Exp: "<!--a- - - -b-->"},
+		{Name: "Starts with >", Comment: Comment(">a"), Exp: "<!-- >a-->"},
+		{Name: "Starts with ->", Comment: Comment("->a"), Exp: "<!-- ->a-->"},
+		{Name: "Ends with dash", Comment: Comment("a <!-"), Exp: "<!--a <!- -->"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Comment, tc.Exp)
+		})
+	}
+
+	assertEqual(t, Div(Comment("x"), Text("y")), "<div><!--x-->y</div>")
+}
+
+func TestCDATA(t *testing.T) {
+	tt := []struct {
+		Name string
+		Node Renderable
+		Exp  string
+	}{
+		{Name: "Simple", Node: CDATA("a < b & c"), Exp: "<![CDATA[a < b & c]]>"},
+		{Name: "Terminator", Node: Script(CDATA("a]]>b")), Exp: "<script><![CDATA[a]]]]><![CDATA[>b]]></script>"},
+		{Name: "Within script", Node: Script(CDATA("</script>")), Exp: `<script><![CDATA[<\/script>]]></script>`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Node, tc.Exp)
+		})
+	}
+}
+
+func TestBogusCommentsCantInjectMarkup(t *testing.T) {
+	payload := "a><img src=x onerror=alert(1)>"
+	for _, node := range []Node{
+		Div(CDATA(payload)),
+		Div(ProcessingInstruction("x", payload)),
+		Textarea(CDATA("</textarea")),
+		Title(ProcessingInstruction("x", "</title")),
+		Script(ProcessingInstruction("x", "</script")),
+		Div(RevealedIfComment("!mso", CDATA(payload))),
+	} {
+		strbuf := &strings.Builder{}
+		err := RenderTo(context.Background(), strbuf, node)
+		var re *RenderError
+		if !errors.As(err, &re) || !errors.Is(err, ErrInvalidNode) {
+			t.Errorf("Expected a *RenderError for ErrInvalidNode, got %v", err)
+		}
+		if strbuf.Len() > 0 {
+			t.Errorf("Expected nothing to be written, got %s", strbuf)
+		}
+		if err := node.Node(context.Background()).Render(context.Background(), &strings.Builder{}); !errors.Is(err, ErrInvalidNode) {
+			t.Errorf("Expected rendering without Resolve to fail too, got %v", err)
+		}
+	}
+
+	// What's accepted parses into a comment, or text within script.
+	for _, tc := range []struct {
+		Node Node
+		Exp  string
+	}{
+		{Node: Div(CDATA("a < b & c")), Exp: "<div>\n  <!--[CDATA[a < b & c]]-->\n"},
+		{Node: Div(ProcessingInstruction("xml-stylesheet", `href="a.css"`)), Exp: "<div>\n  <!--?xml-stylesheet href=\"a.css\"?-->\n"},
+		{Node: Div(ProcessingInstruction("x", "a < b")), Exp: "<div>\n  <!--?x a < b?-->\n"},
+		{Node: Script(CDATA("a > b")), Exp: "<script>\n  \"<![CDATA[a > b]]>\"\n"},
+	} {
+		strbuf := &strings.Builder{}
+		if err := RenderTo(context.Background(), strbuf, tc.Node); err != nil {
+			t.Fatalf("Error rendering: %s", err)
+		}
+		if got := dumpFragment(t, strbuf.String()); got != tc.Exp {
+			t.Errorf("Expected %s to parse into\n%s\ngot\n%s", strbuf, tc.Exp, got)
+		}
+	}
+
+	for _, node := range []Node{Div(CDATA(payload)), Div(ProcessingInstruction("x", payload))} {
+		if err := RenderTo(context.Background(), &strings.Builder{}, node, UseSyntax(XHTMLSyntax)); err != nil {
+			t.Errorf("Expected %q to be allowed in XHTML, got %s", payload, err)
+		}
+	}
+}
+
+// dumpFragment parses src as the content of a body element and describes
+// the nodes it consists of.
+func dumpFragment(t *testing.T, src string) string {
+	t.Helper()
+	nodes, err := html.ParseFragment(strings.NewReader(src), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		t.Fatalf("Error parsing: %s", err)
+	}
+	sb := &strings.Builder{}
+	var dump func(n *html.Node, depth int)
+	dump = func(n *html.Node, depth int) {
+		indent := strings.Repeat("  ", depth)
+		switch n.Type {
+		case html.ElementNode:
+			fmt.Fprintf(sb, "%s<%s>\n", indent, n.Data)
+		case html.TextNode:
+			fmt.Fprintf(sb, "%s%q\n", indent, n.Data)
+		case html.CommentNode:
+			fmt.Fprintf(sb, "%s<!--%s-->\n", indent, n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			dump(c, depth+1)
+		}
+	}
+	for _, n := range nodes {
+		dump(n, 0)
+	}
+	return sb.String()
+}
+
+func TestProcessingInstruction(t *testing.T) {
+	assertEqual(t, ProcessingInstruction("xml-stylesheet", `href="a.css"`).Node(context.Background()), `<?xml-stylesheet href="a.css"?>`)
+	assertEqual(t, ProcessingInstruction("pi", "").Node(context.Background()), `<?pi?>`)
+
+	for _, node := range []Node{ProcessingInstruction("a b", ""), ProcessingInstruction("1a", ""), ProcessingInstruction("a", "?>")} {
+		err := node.Node(context.Background()).Render(context.Background(), &strings.Builder{})
+		if !errors.Is(err, ErrInvalidNode) {
+			t.Errorf("Expected ErrInvalidNode, got %v", err)
+		}
+	}
+}
+
+func TestConditionalComments(t *testing.T) {
+	tt := []struct {
+		Name string
+		Node Renderable
+		Exp  string
+	}{
+		{
+			Name: "If",
+			Node: Div(IfComment("gte mso 9", Table(Tr(Td(Text("a")))))),
+			Exp:  "<div><!--[if gte mso 9]><table><tr><td>a</td></tr></table><![endif]--></div>",
+		},
+		{
+			Name: "Revealed",
+			Node: Div(RevealedIfComment("!mso", P(Text("a")), P(Text("b")))),
+			Exp:  "<div><!--[if !mso]><!--><p>a</p><p>b</p><!--<![endif]--></div>",
+		},
+		{
+			Name: "Revealed with comment",
+			Node: RevealedIfComment("(IE 8)|(IE 9)", Comment("x")).Node(context.Background()),
+			Exp:  "<!--[if (IE 8)|(IE 9)]><!--><!--x--><!--<![endif]-->",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Node, tc.Exp)
+		})
+	}
+
+	for _, node := range []Node{IfComment("mso]>", Text("x")), IfComment(""), IfComment("mso", Comment("x"))} {
+		err := Div(node).Render(context.Background(), &strings.Builder{})
+		if !errors.Is(err, ErrInvalidNode) {
+			t.Errorf("Expected ErrInvalidNode, got %v", err)
+		}
+	}
+}
+
+func TestInvalidCommentNodesHavePaths(t *testing.T) {
+	tt := []struct {
+		Name string
+		Node Node
+		Path string
+	}{
+		{Name: "Processing instruction", Node: HTML(Body(Div(), Div(ProcessingInstruction("1x", "")))), Path: "html > body > div[2]"},
+		{Name: "Condition", Node: Body(P(), Div(IfComment("mso]>", Text("x")))), Path: "body > div"},
+		{Name: "Content", Node: Body(Ul(Li(), Li(IfComment("mso", Comment("x"))))), Path: "body > ul > li[2]"},
+		{Name: "Within a conditional comment", Node: Body(RevealedIfComment("!mso", Div(ProcessingInstruction("a", "?>")))), Path: "body > div"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := Resolve(context.Background(), tc.Node)
+			var re *RenderError
+			if !errors.As(err, &re) {
+				t.Fatalf("Expected a *RenderError, got %v", err)
+			}
+			if path := strings.Join(re.Path, " > "); path != tc.Path {
+				t.Errorf("Expected path %s, got %s", tc.Path, path)
+			}
+			if !errors.Is(err, ErrInvalidNode) {
+				t.Errorf("Expected ErrInvalidNode, got %v", err)
+			}
+
+			strbuf := &strings.Builder{}
+			if err := RenderTo(context.Background(), strbuf, tc.Node); !errors.As(err, &re) || strbuf.Len() > 0 {
+				t.Errorf("Expected a *RenderError before any output, got %v and %q", err, strbuf)
+			}
+		})
+	}
+
+	err := Div(ProcessingInstruction("1x", "")).Render(context.Background(), &strings.Builder{})
+	var re *RenderError
+	if !errors.As(err, &re) || strings.Join(re.Path, " > ") != "div" {
+		t.Errorf("Expected a *RenderError at div, got %v", err)
+	}
+}
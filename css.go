@@ -0,0 +1,64 @@
+package yahw
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+// UseCSS registers css for the page being rendered, usually from a
+// component's Node method. RenderTo renders all of it in a single style
+// element in the head, or in front of everything if there's no head. CSS
+// registered under a key that's already been used is ignored, so each
+// component's styles are only included once, however many times it's used.
+// It does nothing outside RenderTo.
+func UseCSS(ctx context.Context, key, css string) {
+	doc := documentOf(ctx)
+	if doc == nil {
+		return
+	}
+	if doc.cssKeys == nil {
+		doc.cssKeys = map[string]bool{}
+	}
+	if doc.cssKeys[key] {
+		return
+	}
+	doc.cssKeys[key] = true
+	doc.css = append(doc.css, css)
+}
+
+// UseScopedCSS is like UseCSS, but keeps css from applying outside of the
+// component. Every "&" in css is replaced with a class name derived from key
+// and css, which is returned to be put on the component's root element:
+//
+//	cls := yahw.UseScopedCSS(ctx, "card", "& { padding: 1em } & h2 { margin: 0 }")
+//	return yahw.Div(cls, yahw.H2(yahw.Text(c.Title)))
+func UseScopedCSS(ctx context.Context, key, css string) Classes {
+	class := scopedClass(key, css)
+	UseCSS(ctx, key, strings.ReplaceAll(css, "&", "."+class))
+	return Classes(class)
+}
+
+// scopedClass returns key with anything that isn't allowed in a class
+// selector replaced, followed by a hash of css.
+func scopedClass(key, css string) string {
+	var sb strings.Builder
+	for _, r := range key {
+		switch {
+		case isASCIILetter(r), '0' <= r && r <= '9', r == '-', r == '_':
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte('-')
+		}
+	}
+	name := sb.String()
+	if name == "" || !isASCIILetter(rune(name[0])) {
+		name = "c" + name
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	h.Write([]byte(css))
+	return fmt.Sprintf("%s-%08x", name, h.Sum32())
+}
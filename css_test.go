@@ -0,0 +1,89 @@
+package yahw
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+type card struct {
+	title string
+}
+
+func (c card) Node(ctx context.Context) Renderable {
+	UseCSS(ctx, "card", ".card { padding: 1em }")
+	return Div(Classes("card"), H2(Text(c.title)))
+}
+
+// nodeFunc is a component built from a function.
+type nodeFunc func(ctx context.Context) Renderable
+
+func (f nodeFunc) Node(ctx context.Context) Renderable { return f(ctx) }
+
+type badge struct{}
+
+func (b badge) Node(ctx context.Context) Renderable {
+	cls := UseScopedCSS(ctx, "badge", "& { color: red } & b { font-weight: 400 }")
+	return Span(cls, B(Text("!")))
+}
+
+func renderString(t *testing.T, node Node, opts ...RenderOption) string {
+	t.Helper()
+	strbuf := &strings.Builder{}
+	if err := RenderTo(context.Background(), strbuf, node, opts...); err != nil {
+		t.Fatalf("Error rendering: %s", err)
+	}
+	return strbuf.String()
+}
+
+func TestUseCSS(t *testing.T) {
+	page := NewHTML5Doctype(HTML(
+		Head(Title(Text("t"))),
+		Body(card{title: "a"}, card{title: "b"}, badge{}),
+	))
+	cls := scopedClass("badge", "& { color: red } & b { font-weight: 400 }")
+	exp := "<!DOCTYPE html><html><head><title>t</title><style>.card { padding: 1em }\n." + cls + " { color: red } ." + cls + " b { font-weight: 400 }</style></head>" +
+		`<body><div class="card"><h2>a</h2></div><div class="card"><h2>b</h2></div><span class="` + cls + `"><b>!</b></span></body></html>`
+	if got := renderString(t, page); got != exp {
+		t.Errorf("Expected:\n%s\ngot:\n%s", exp, got)
+	}
+}
+
+func TestUseCSSWithoutHead(t *testing.T) {
+	exp := `<style>.card { padding: 1em }</style><div class="card"><h2>a</h2></div>`
+	if got := renderString(t, card{title: "a"}); got != exp {
+		t.Errorf("Expected %s, got %s", exp, got)
+	}
+}
+
+func TestUseCSSWithinConditionals(t *testing.T) {
+	page := HTML(Head(), Body(If(false, card{}), When(func(ctx context.Context) bool { return true }, func() Node { return card{title: "x"} })))
+	exp := `<html><head><style>.card { padding: 1em }</style></head><body><div class="card"><h2>x</h2></div></body></html>`
+	if got := renderString(t, page); got != exp {
+		t.Errorf("Expected %s, got %s", exp, got)
+	}
+}
+
+func TestUseCSSEscaping(t *testing.T) {
+	node := Fragment{Head(), nodeFunc(func(ctx context.Context) Renderable {
+		UseCSS(ctx, "x", "a::after { content: '</style><script>' }")
+		return nil
+	})}
+	exp := `<head><style>a::after { content: '<\/style><script>' }</style></head>`
+	if got := renderString(t, node); got != exp {
+		t.Errorf("Expected %s, got %s", exp, got)
+	}
+}
+
+func TestUseCSSOutsideRenderTo(t *testing.T) {
+	assertEqual(t, Div(card{title: "a"}), `<div><div class="card"><h2>a</h2></div></div>`)
+}
+
+func TestScopedClass(t *testing.T) {
+	if a, b := scopedClass("x", "a"), scopedClass("x", "b"); a == b || !strings.HasPrefix(a, "x-") {
+		t.Errorf("Expected distinct classes prefixed with x-, got %s and %s", a, b)
+	}
+	if cls := scopedClass("1 a.b", "a"); !strings.HasPrefix(cls, "c1-a-b-") {
+		t.Errorf("Expected a valid class, got %s", cls)
+	}
+}
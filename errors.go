@@ -0,0 +1,76 @@
+package yahw
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidTagName       = errors.New("invalid tag name")
+	ErrInvalidAttrName      = errors.New("invalid attribute name")
+	ErrInvalidNode          = errors.New("invalid node")
+	ErrInvalidStyleProperty = errors.New("invalid style property")
+	ErrInvalidSelector      = errors.New("invalid selector")
+)
+
+// RenderError is returned when a tag can't be rendered. Path leads from the
+// outermost tag to the offending one, e.g. html > body > div[2] > span, where
+// [2] means the second div within the body.
+type RenderError struct {
+	Path []string
+	Err  error
+}
+
+func (e *RenderError) Error() string {
+	return fmt.Sprintf("%s: %s", strings.Join(e.Path, " > "), e.Err)
+}
+
+func (e *RenderError) Unwrap() error { return e.Err }
+
+// newRenderError wraps err unless it was already wrapped by a child tag.
+func newRenderError(tagName string, err error) error {
+	var re *RenderError
+	if errors.As(err, &re) {
+		return err
+	}
+	return &RenderError{Path: []string{tagName}, Err: err}
+}
+
+func elementName(r Renderable) (string, bool) {
+	switch t := r.(type) {
+	case CommonTag:
+		return t.tagName, true
+	case SelfClosingTag:
+		return t.tagName, true
+	}
+	return "", false
+}
+
+// wrapChildError prepends tagName to the path of err, which was returned while
+// rendering children[idx]. Other errors, like those of the writer, are
+// returned as they are.
+func wrapChildError(tagName string, children []taggable, idx int, err error) error {
+	re, ok := err.(*RenderError)
+	if !ok {
+		return err
+	}
+
+	if name, ok := elementName(children[idx]); ok && len(re.Path) > 0 {
+		pos, total := 0, 0
+		for i, child := range children {
+			if n, ok := elementName(child); ok && n == name {
+				total++
+				if i <= idx {
+					pos++
+				}
+			}
+		}
+		if total > 1 {
+			re.Path[0] = fmt.Sprintf("%s[%d]", re.Path[0], pos)
+		}
+	}
+
+	re.Path = append([]string{tagName}, re.Path...)
+	return re
+}
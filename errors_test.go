@@ -0,0 +1,113 @@
+package yahw
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type renderFunc func(ctx context.Context, w io.Writer) error
+
+func (f renderFunc) Render(ctx context.Context, w io.Writer) error { return f(ctx, w) }
+
+// untagged is a component rendering to something that's neither a tag nor an
+// attribute.
+type untagged struct{}
+
+func (u untagged) Node(ctx context.Context) Renderable {
+	return renderFunc(func(ctx context.Context, w io.Writer) error { return nil })
+}
+
+func TestTryConstructors(t *testing.T) {
+	if _, err := TryBuildAttr("foo bar", "x"); !errors.Is(err, ErrInvalidAttrName) {
+		t.Errorf("Expected ErrInvalidAttrName, got %v", err)
+	}
+	if _, err := TryNoValAttr(""); !errors.Is(err, ErrInvalidAttrName) {
+		t.Errorf("Expected ErrInvalidAttrName, got %v", err)
+	}
+	if _, err := TryTagBuilder("foo bar"); !errors.Is(err, ErrInvalidTagName) {
+		t.Errorf("Expected ErrInvalidTagName, got %v", err)
+	}
+	if _, err := TrySelfClosingTagBuilder("foo>"); !errors.Is(err, ErrInvalidTagName) {
+		t.Errorf("Expected ErrInvalidTagName, got %v", err)
+	}
+
+	attr, err := TryBuildAttr("foo", "bar")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertEqual(t, attr, `foo="bar"`)
+
+	foo, err := TryTagBuilder("foo")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	assertEqual(t, foo(attr), `<foo foo="bar"></foo>`)
+}
+
+func TestRenderErrors(t *testing.T) {
+	tt := []struct {
+		Name string
+		Tag  Renderable
+		Err  error
+		Path string
+	}{
+		{Name: "Invalid node", Tag: Div(untagged{}), Err: ErrInvalidNode, Path: "div"},
+		{Name: "Nested invalid node", Tag: HTML(Body(Div(), Div(Span(untagged{})))), Err: ErrInvalidNode, Path: "html > body > div[2] > span"},
+		{Name: "Single child has no index", Tag: Ul(Li(), Li(P(untagged{})), Li()), Err: ErrInvalidNode, Path: "ul > li[2] > p"},
+		{Name: "Invalid tag name", Tag: Div(NewTag("foo bar")), Err: ErrInvalidTagName, Path: "div > foo bar"},
+		{Name: "Invalid self-closing tag", Tag: Div(Br(), SelfClosingTag{tagName: "<br>"}), Err: ErrInvalidTagName, Path: "div > <br>"},
+		{Name: "Invalid class merge", Tag: Div(Classes("a"), NoValAttr("class")), Path: "div"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			var err error
+			assertNotPanic(t, func() { err = tc.Tag.Render(context.Background(), &strings.Builder{}) })
+
+			var re *RenderError
+			if !errors.As(err, &re) {
+				t.Fatalf("Expected *RenderError, got %v", err)
+			}
+			if tc.Err != nil && !errors.Is(err, tc.Err) {
+				t.Errorf("Expected %v, got %v", tc.Err, err)
+			}
+			if path := strings.Join(re.Path, " > "); path != tc.Path {
+				t.Errorf("Expected path %s, got %s", tc.Path, path)
+			}
+		})
+	}
+}
+
+func TestRenderErrorWithStrictAttrs(t *testing.T) {
+	ctx := WithStrictAttrs(context.Background())
+	tag := HTML(Body(Div(), Div(Input(ID("a"), ID("b")))))
+
+	err := tag.Render(ctx, &strings.Builder{})
+	var conflict *AttrConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("Expected *AttrConflictError, got %v", err)
+	}
+	if exp := `html > body > div[2] > input: conflicting values for attribute id: ["a" "b"]`; err.Error() != exp {
+		t.Errorf("Expected %s, got %s", exp, err)
+	}
+}
+
+func TestWriteErrorsAreNotWrapped(t *testing.T) {
+	err := Div(Span()).Render(context.Background(), &failingWriter{})
+	var re *RenderError
+	if err == nil || errors.As(err, &re) {
+		t.Errorf("Expected the writer's error, got %v", err)
+	}
+}
+
+func assertNotPanic(t *testing.T, f func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("Unexpected panic: %v", r)
+		}
+	}()
+	f()
+}
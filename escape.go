@@ -0,0 +1,85 @@
+package yahw
+
+import (
+	"context"
+	"html"
+	"strings"
+)
+
+// elementKind describes how browsers parse the text content of an element.
+type elementKind int
+
+const (
+	// normalElement content is parsed as HTML, so text must be escaped.
+	normalElement elementKind = iota
+	// rawTextElement content (script, style) is never decoded, so entities
+	// would be rendered literally. Only the closing tag must be avoided.
+	rawTextElement
+	// rcdataElement content (title, textarea) can't contain tags, but
+	// character references are still decoded.
+	rcdataElement
+)
+
+func kindOf(tagName string) elementKind {
+	switch strings.ToLower(tagName) {
+	case "script", "style":
+		return rawTextElement
+	case "title", "textarea":
+		return rcdataElement
+	default:
+		return normalElement
+	}
+}
+
+type parentTagKey struct{}
+
+// withParentTag records the element whose children are about to be rendered.
+func withParentTag(ctx context.Context, tagName string) context.Context {
+	return context.WithValue(ctx, parentTagKey{}, tagName)
+}
+
+// parentTag returns the name of the closest enclosing element, if any.
+func parentTag(ctx context.Context) string {
+	tagName, _ := ctx.Value(parentTagKey{}).(string)
+	return tagName
+}
+
+// escapeText escapes s so it's rendered as text within the current parent
+// element. XHTML has no raw text elements, so everything is escaped.
+func escapeText(ctx context.Context, s string) string {
+	tagName := parentTag(ctx)
+	switch kindOf(tagName) {
+	case rawTextElement:
+		if syntaxOf(ctx) == XHTMLSyntax {
+			return html.EscapeString(s)
+		}
+		return escapeRawText(tagName, s)
+	default:
+		return html.EscapeString(s)
+	}
+}
+
+// escapeRawText neutralizes anything in s that would end the raw text element
+// tagName early. Both "<\/" and "<\!" are harmless in JS and CSS alike.
+func escapeRawText(tagName string, s string) string {
+	if !strings.Contains(s, "<") {
+		return s
+	}
+
+	closing := "</" + strings.ToLower(tagName)
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		rest := s[i:]
+		switch {
+		case len(rest) >= len(closing) && strings.EqualFold(rest[:len(closing)], closing):
+			sb.WriteString(`<\/`)
+			i++
+		case strings.HasPrefix(rest, "<!--"):
+			sb.WriteString(`<\!`)
+			i++
+		default:
+			sb.WriteByte(s[i])
+		}
+	}
+	return sb.String()
+}
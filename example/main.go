@@ -13,8 +13,9 @@ type MyCustomButton struct {
 }
 
 func (m MyCustomButton) Node(ctx context.Context) Renderable {
+	UseCSS(ctx, "my-custom-button", "button { padding: 10px; border: none; }")
 	return Button(
-		BuildAttr("style", "background-color: "+m.BackgroundColor),
+		Styles{"background-color": m.BackgroundColor},
 		Text(m.Text),
 	)
 }
@@ -35,33 +36,31 @@ func main() {
 		root := NewHTML5Doctype(
 			HTML(
 				Head(
-					Title((Text("My Custom Button Example")),
-						Style((Text("button { padding: 10px; border: none; }"))),
-						Body(
-							MyCustomButton{
-								Text:            "Click me!",
-								BackgroundColor: "red",
-							},
-							Br(),
-							MyCustomButton{
-								Text:            "No, click me!",
-								BackgroundColor: "green",
-							},
-							Br(),
-							MyCustomInput("name", "Enter your name"),
-							Br(),
-							MyCustomInput("email", "Enter your email"),
-							Br(),
-							A(MyCommonAttributes("https://example1.com"), Text("Click me!")),
-							Br(),
-							A(MyCommonAttributes("https://example2.com"), Text("No, click me!")),
-						),
-					),
+					Title(Text("My Custom Button Example")),
+				),
+				Body(
+					MyCustomButton{
+						Text:            "Click me!",
+						BackgroundColor: "red",
+					},
+					Br(),
+					MyCustomButton{
+						Text:            "No, click me!",
+						BackgroundColor: "green",
+					},
+					Br(),
+					MyCustomInput("name", "Enter your name"),
+					Br(),
+					MyCustomInput("email", "Enter your email"),
+					Br(),
+					A(MyCommonAttributes("https://example1.com"), Text("Click me!")),
+					Br(),
+					A(MyCommonAttributes("https://example2.com"), Text("No, click me!")),
 				),
 			),
 		)
 
-		err := root.Render(w)
+		err := RenderTo(r.Context(), w, root)
 		if err != nil {
 			panic(err)
 		}
@@ -0,0 +1,57 @@
+package yahw
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Fragment groups nodes without wrapping them in a tag. Within a tag, its
+// nodes are flattened into the tag's children, so a Fragment can also carry
+// attributes for it. It can be returned from a component's Node method and
+// used anywhere a tag can.
+type Fragment []Node
+
+// Nodes is the original name of Fragment.
+type Nodes = Fragment
+
+var (
+	_ Node     = Fragment{}
+	_ taggable = Fragment{}
+)
+
+func (f Fragment) tag()                                {}
+func (f Fragment) Node(ctx context.Context) Renderable { return f }
+
+func (f Fragment) Render(ctx context.Context, w io.Writer) error {
+	children := unwrapNodes(ctx, f)
+	for _, r := range children {
+		if _, ok := r.(attrable); ok {
+			return fmt.Errorf("%w: attribute %T outside of a tag", ErrInvalidNode, r)
+		}
+	}
+
+	l, indented := layoutOf(ctx)
+	breaks := indented && breaksBetween(l, children)
+	m, minified := minifyOf(ctx)
+	first := true
+	for idx, child := range children {
+		if child == nil {
+			continue
+		}
+		if breaks && !first {
+			if err := l.newline(w, l.depth); err != nil {
+				return err
+			}
+		}
+		childCtx := ctx
+		if minified {
+			childCtx = withMinify(ctx, minifyStateAt(m, children, idx))
+		}
+		if err := child.Render(childCtx, w); err != nil {
+			return err
+		}
+		first = false
+	}
+	return nil
+}
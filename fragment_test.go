@@ -0,0 +1,81 @@
+package yahw
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type listItems struct {
+	items []string
+}
+
+func (l listItems) Node(ctx context.Context) Renderable {
+	return ForEach(l.items, func(i int, item string) Node { return Li(Text(item)) })
+}
+
+func TestFragment(t *testing.T) {
+	tt := []struct {
+		Name string
+		Node Renderable
+		Exp  string
+	}{
+		{Name: "Top level", Node: Fragment{P(Text("a")), P(Text("b"))}, Exp: "<p>a</p><p>b</p>"},
+		{Name: "Empty", Node: Fragment{}, Exp: ""},
+		{Name: "Within tag", Node: Div(Fragment{Span(), Text("x")}), Exp: "<div><span></span>x</div>"},
+		{Name: "Attributes for parent", Node: Div(Fragment{ID("a"), Text("x")}), Exp: `<div id="a">x</div>`},
+		{Name: "Nested", Node: Fragment{Fragment{Text("a"), Nodes{Text("b")}}, Text("c")}, Exp: "abc"},
+		{Name: "Within IfElse", Node: Div(If(true, Fragment{Span(), Span()})), Exp: "<div><span></span><span></span></div>"},
+		{Name: "Nodes within IfElse", Node: Div(If(false, Nodes{}).Else(Nodes{ID("x"), Text("y")})), Exp: `<div id="x">y</div>`},
+		{Name: "Returned from component", Node: Ul(Classes("list"), listItems{items: []string{"a", "b"}}), Exp: `<ul class="list"><li>a</li><li>b</li></ul>`},
+		{Name: "Component at top level", Node: listItems{items: []string{"a"}}.Node(context.Background()), Exp: "<li>a</li>"},
+		{Name: "Within TagSlice", Node: TagSlice{P(), Fragment{Br(), Text("x")}}, Exp: "<p></p><br />x"},
+		{Name: "Within doctype", Node: NewHTML5Doctype(Fragment{Comment("x"), HTML()}), Exp: "<!DOCTYPE html><!--x--><html></html>"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Node, tc.Exp)
+		})
+	}
+}
+
+func TestFragmentRenderTo(t *testing.T) {
+	strbuf := &strings.Builder{}
+	err := RenderTo(context.Background(), strbuf, listItems{items: []string{"a", "b"}})
+	if err != nil {
+		t.Fatalf("Error rendering: %s", err)
+	}
+	if exp := "<li>a</li><li>b</li>"; strbuf.String() != exp {
+		t.Errorf("Expected %s, got %s", exp, strbuf.String())
+	}
+}
+
+func TestFragmentWithAttributeOutsideOfTag(t *testing.T) {
+	err := Fragment{ID("x")}.Render(context.Background(), &strings.Builder{})
+	if !errors.Is(err, ErrInvalidNode) {
+		t.Errorf("Expected ErrInvalidNode, got %v", err)
+	}
+}
+
+func TestContainersAcceptNodes(t *testing.T) {
+	tt := []struct {
+		Name string
+		Node Renderable
+		Exp  string
+	}{
+		{Name: "Component in doctype", Node: NewHTML5Doctype(listItems{items: []string{"a"}}), Exp: "<!DOCTYPE html><li>a</li>"},
+		{Name: "If in doctype", Node: NewHTML5Doctype(If(true, HTML()).Else(Text("no"))), Exp: "<!DOCTYPE html><html></html>"},
+		{Name: "Nil in doctype", Node: NewHTML5Doctype(nil, If(false, HTML())), Exp: "<!DOCTYPE html>"},
+		{Name: "Component in TagSlice", Node: TagSlice{P(), listItems{items: []string{"a", "b"}}}, Exp: "<p></p><li>a</li><li>b</li>"},
+		{Name: "Switch in TagSlice", Node: TagSlice{Switch(2).Case(2, Br())}, Exp: "<br />"},
+		{Name: "TagSlice in tag", Node: Div(TagSlice{Span(), Text("x")}), Exp: "<div><span></span>x</div>"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Node, tc.Exp)
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package yahw
+
+import (
+	"context"
+	"strings"
+)
+
+type documentKey struct{}
+
+// document collects what components register while they're resolved, to be
+// rendered in the head of the page.
+type document struct {
+	title   *string
+	metas   []headItem
+	links   []headItem
+	scripts []headItem
+	cssKeys map[string]bool
+	css     []string
+}
+
+// headItem is an element for the head, identified by key. Elements without
+// a key are never replaced.
+type headItem struct {
+	key  string
+	node Node
+}
+
+func withDocument(ctx context.Context, doc *document) context.Context {
+	return context.WithValue(ctx, documentKey{}, doc)
+}
+
+func documentOf(ctx context.Context) *document {
+	doc, _ := ctx.Value(documentKey{}).(*document)
+	return doc
+}
+
+// SetTitle sets the title of the page being rendered. The last title set
+// replaces the title element in the head. It does nothing outside RenderTo.
+func SetTitle(ctx context.Context, title string) {
+	if doc := documentOf(ctx); doc != nil {
+		doc.title = &title
+	}
+}
+
+// AddMeta adds a meta element with attrs to the head of the page being
+// rendered. A meta element with the same name, property, http-equiv or
+// charset, whether added earlier or already in the head, is replaced. It
+// does nothing outside RenderTo.
+func AddMeta(ctx context.Context, attrs ...attrable) {
+	if doc := documentOf(ctx); doc != nil {
+		doc.metas = addHeadItem(doc.metas, metaKey(ctx, attrs), Meta(attrs...))
+	}
+}
+
+// AddLink adds a link element to the head of the page being rendered, e.g.
+// AddLink(ctx, "stylesheet", "/main.css"). Links with the same rel and href
+// are only added once. It does nothing outside RenderTo.
+func AddLink(ctx context.Context, rel, href string, attrs ...attrable) {
+	if doc := documentOf(ctx); doc != nil {
+		link := Link(append([]attrable{Rel(rel), Href(href)}, attrs...)...)
+		doc.links = addHeadItem(doc.links, rel+" "+href, link)
+	}
+}
+
+// AddScript adds a script element loading src to the head of the page being
+// rendered, after everything else added to it. Each src is only loaded once.
+// It does nothing outside RenderTo.
+func AddScript(ctx context.Context, src string, attrs ...attrable) {
+	if doc := documentOf(ctx); doc != nil {
+		children := []Node{Src(src)}
+		for _, attr := range attrs {
+			children = append(children, asNode(attr))
+		}
+		doc.scripts = addHeadItem(doc.scripts, src, Script(children...))
+	}
+}
+
+// addHeadItem adds node to items. If an item has the same key, node takes
+// its place.
+func addHeadItem(items []headItem, key string, node Node) []headItem {
+	if key != "" {
+		for i, item := range items {
+			if item.key == key {
+				items[i].node = node
+				return items
+			}
+		}
+	}
+	return append(items, headItem{key: key, node: node})
+}
+
+// metaKey identifies a meta element by the attribute saying what it's about.
+func metaKey(ctx context.Context, attrs []attrable) string {
+	flat, err := flattenAttrs(ctx, attrs)
+	if err != nil {
+		return ""
+	}
+	for _, attr := range flat {
+		named, ok := attr.(namedAttr)
+		if !ok {
+			continue
+		}
+		switch key := strings.ToLower(named.attrKey()); key {
+		case "charset":
+			return key
+		case "name", "property", "http-equiv", "itemprop":
+			return key + "=" + strings.ToLower(named.attrValue())
+		}
+	}
+	return ""
+}
+
+func (d *document) empty() bool {
+	return d.title == nil && len(d.metas) == 0 && len(d.links) == 0 && len(d.scripts) == 0 && len(d.css) == 0
+}
+
+// fillHead returns children of a head element with everything registered in
+// d added. The title and meta elements it replaces keep their position.
+func (d *document) fillHead(ctx context.Context, children []Node) []Node {
+	metas := map[string]Node{}
+	for _, item := range d.metas {
+		if item.key != "" {
+			metas[item.key] = item.node
+		}
+	}
+
+	res := make([]Node, 0, len(children))
+	placedTitle := d.title == nil
+	placedMetas := map[string]bool{}
+	for _, child := range children {
+		r, _ := child.(Renderable)
+		name, attrs := elementOf(r)
+		switch {
+		case name == "title" && d.title != nil:
+			if !placedTitle {
+				res = append(res, Title(Text(*d.title)))
+				placedTitle = true
+			}
+			continue
+		case name == "meta":
+			key := metaKey(ctx, attrs)
+			if meta, ok := metas[key]; ok {
+				if !placedMetas[key] {
+					res = append(res, meta)
+					placedMetas[key] = true
+				}
+				continue
+			}
+		}
+		res = append(res, child)
+	}
+
+	if !placedTitle {
+		res = append(res, Title(Text(*d.title)))
+	}
+	for _, item := range d.metas {
+		if item.key == "" || !placedMetas[item.key] {
+			res = append(res, item.node)
+		}
+	}
+	for _, item := range d.links {
+		res = append(res, item.node)
+	}
+	if len(d.css) > 0 {
+		res = append(res, Style(Text(strings.Join(d.css, "\n"))))
+	}
+	for _, item := range d.scripts {
+		res = append(res, item.node)
+	}
+	return res
+}
+
+// elementOf returns the lowercased name and the attributes of the element r,
+// or an empty name if r isn't an element.
+func elementOf(r Renderable) (string, []attrable) {
+	switch t := r.(type) {
+	case SelfClosingTag:
+		return strings.ToLower(t.tagName), t.attrs
+	case CommonTag:
+		attrs := []attrable{}
+		for _, child := range t.children {
+			if attr, ok := child.(attrable); ok {
+				attrs = append(attrs, attr)
+			}
+		}
+		return strings.ToLower(t.tagName), attrs
+	}
+	return "", nil
+}
@@ -0,0 +1,90 @@
+package yahw
+
+import (
+	"context"
+	"testing"
+)
+
+type productPage struct {
+	name string
+}
+
+func (p productPage) Node(ctx context.Context) Renderable {
+	SetTitle(ctx, p.name+" | Shop")
+	AddMeta(ctx, Name("description"), Content("Buy "+p.name))
+	AddMeta(ctx, BuildAttr("property", "og:title"), Content(p.name))
+	AddLink(ctx, "canonical", "https://shop.example/"+p.name)
+	AddScript(ctx, "/cart.js", BoolAttr("defer", true))
+	return Div(H1(Text(p.name)), addToCart{}, addToCart{})
+}
+
+type addToCart struct{}
+
+func (a addToCart) Node(ctx context.Context) Renderable {
+	AddScript(ctx, "/cart.js", BoolAttr("defer", true))
+	AddLink(ctx, "stylesheet", "/cart.css")
+	return Button(Text("Add to cart"))
+}
+
+func TestHeadCollection(t *testing.T) {
+	page := NewHTML5Doctype(HTML(
+		Head(
+			Meta(Charset("utf-8")),
+			Title(Text("Shop")),
+			Meta(Name("description"), Content("A shop")),
+			Meta(Name("viewport"), Content("width=device-width")),
+		),
+		Body(productPage{name: "tea"}),
+	))
+	exp := `<!DOCTYPE html><html><head>` +
+		`<meta charset="utf-8" />` +
+		`<title>tea | Shop</title>` +
+		`<meta name="description" content="Buy tea" />` +
+		`<meta name="viewport" content="width=device-width" />` +
+		`<meta property="og:title" content="tea" />` +
+		`<link rel="canonical" href="https://shop.example/tea" />` +
+		`<link rel="stylesheet" href="/cart.css" />` +
+		`<script src="/cart.js" defer></script>` +
+		`</head><body><div><h1>tea</h1><button>Add to cart</button><button>Add to cart</button></div></body></html>`
+	if got := renderString(t, page); got != exp {
+		t.Errorf("Expected:\n%s\ngot:\n%s", exp, got)
+	}
+}
+
+func TestHeadCollectionOrder(t *testing.T) {
+	page := HTML(Head(), Body(
+		nodeFunc(func(ctx context.Context) Renderable {
+			SetTitle(ctx, "a")
+			AddMeta(ctx, Name("x"), Content("1"))
+			AddMeta(ctx, Name("robots"), Content("none"))
+			UseCSS(ctx, "c", "p { margin: 0 }")
+			AddScript(ctx, "/b.js")
+			return nil
+		}),
+		nodeFunc(func(ctx context.Context) Renderable {
+			SetTitle(ctx, "b")
+			AddMeta(ctx, Name("X"), Content("2"))
+			AddScript(ctx, "/a.js")
+			return nil
+		}),
+	))
+	exp := `<html><head><title>b</title><meta name="X" content="2" /><meta name="robots" content="none" />` +
+		`<style>p { margin: 0 }</style><script src="/b.js"></script><script src="/a.js"></script></head><body></body></html>`
+	if got := renderString(t, page); got != exp {
+		t.Errorf("Expected:\n%s\ngot:\n%s", exp, got)
+	}
+}
+
+func TestHeadCollectionWithoutHead(t *testing.T) {
+	node := nodeFunc(func(ctx context.Context) Renderable {
+		SetTitle(ctx, "a")
+		return P(Text("x"))
+	})
+	if got, exp := renderString(t, node), "<title>a</title><p>x</p>"; got != exp {
+		t.Errorf("Expected %s, got %s", exp, got)
+	}
+}
+
+func TestHeadCollectionOutsideRenderTo(t *testing.T) {
+	assertEqual(t, HTML(Head(), Body(productPage{name: "tea"})), `<html><head></head><body><div><h1>tea</h1><button>Add to cart</button><button>Add to cart</button></div></body></html>`)
+}
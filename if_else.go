@@ -2,15 +2,25 @@ package yahw
 
 import (
 	"context"
+	"io"
 )
 
+// evaluator is implemented by conditional nodes, so they can be resolved
+// where only attributes are accepted.
+type evaluator interface {
+	evaluate(ctx context.Context) Renderable
+}
+
 type IfElse struct {
 	cond bool
 	then Node
 	els  Node
 }
 
-var _ Node = IfElse{}
+var (
+	_ Node     = IfElse{}
+	_ attrable = IfElse{}
+)
 
 func If(cond bool, then Node) IfElse {
 	return IfElse{cond: cond, then: then}
@@ -21,6 +31,8 @@ func (ie IfElse) Else(els Node) IfElse {
 	return ie
 }
 
+func (ie IfElse) attr() {}
+
 // Node implements Node.
 func (ie IfElse) Node(ctx context.Context) Renderable {
 	eval := ie.evaluate(ctx)
@@ -30,12 +42,79 @@ func (ie IfElse) Node(ctx context.Context) Renderable {
 	return eval
 }
 
+// Render renders the selected branch. It's what allows IfElse within the
+// attributes of a SelfClosingTag.
+func (ie IfElse) Render(ctx context.Context, w io.Writer) error {
+	return renderEvaluated(ctx, w, ie.evaluate(ctx))
+}
+
 func (t IfElse) evaluate(ctx context.Context) Renderable {
 	if t.cond {
-		return t.then.Node(ctx)
+		return resolve(ctx, t.then)
 	}
-	if t.els != nil {
-		return t.els.Node(ctx)
+	return resolve(ctx, t.els)
+}
+
+// WhenElse is a lazy IfElse: neither the condition nor the branches are
+// evaluated until it's rendered.
+type WhenElse struct {
+	cond func(ctx context.Context) bool
+	then func() Node
+	els  func() Node
+}
+
+var (
+	_ Node     = WhenElse{}
+	_ attrable = WhenElse{}
+)
+
+// When renders the node built by then if cond returns true. Use it instead of
+// If when building the node is expensive.
+func When(cond func(ctx context.Context) bool, then func() Node) WhenElse {
+	return WhenElse{cond: cond, then: then}
+}
+
+func (we WhenElse) Else(els func() Node) WhenElse {
+	we.els = els
+	return we
+}
+
+func (we WhenElse) attr() {}
+
+func (we WhenElse) Node(ctx context.Context) Renderable {
+	eval := we.evaluate(ctx)
+	if eval == nil {
+		return nil
+	}
+	return eval
+}
+
+func (we WhenElse) Render(ctx context.Context, w io.Writer) error {
+	return renderEvaluated(ctx, w, we.evaluate(ctx))
+}
+
+func (we WhenElse) evaluate(ctx context.Context) Renderable {
+	build := we.els
+	if we.cond != nil && we.cond(ctx) {
+		build = we.then
+	}
+	if build == nil {
+		return nil
+	}
+	return resolve(ctx, build())
+}
+
+// resolve returns n.Node(ctx), or nil if n is nil.
+func resolve(ctx context.Context, n Node) Renderable {
+	if n == nil {
+		return nil
+	}
+	return n.Node(ctx)
+}
+
+func renderEvaluated(ctx context.Context, w io.Writer, r Renderable) error {
+	if r == nil {
+		return nil
 	}
-	return nil
+	return r.Render(ctx, w)
 }
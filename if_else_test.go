@@ -0,0 +1,79 @@
+package yahw
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestIfElse(t *testing.T) {
+	assertEqual(t, Div(If(true, Text("yes")).Else(Text("no"))), "<div>yes</div>")
+	assertEqual(t, Div(If(false, Text("yes")).Else(Text("no"))), "<div>no</div>")
+	assertEqual(t, Div(If(false, Text("yes"))), "<div></div>")
+	assertEqual(t, Div(If(true, ID("a")), If(false, ID("b")).Else(Classes("c"))), `<div id="a" class="c"></div>`)
+}
+
+func TestIfElseInSelfClosingTag(t *testing.T) {
+	tt := []struct {
+		Name string
+		Tag  Renderable
+		Exp  string
+	}{
+		{Name: "Then", Tag: Input(Type("checkbox"), If(true, Checked())), Exp: `<input type="checkbox" checked />`},
+		{Name: "Else", Tag: Input(If(false, Type("text")).Else(Type("email"))), Exp: `<input type="email" />`},
+		{Name: "Nothing", Tag: Input(If(false, Checked()), Name("x")), Exp: `<input name="x" />`},
+		{Name: "Merged classes", Tag: Img(Classes("a"), If(true, Classes("b"))), Exp: `<img class="a b" />`},
+		{Name: "Attribute slice", Tag: Img(If(true, AttrSlice{Alt("x"), Src("/a.png")})), Exp: `<img alt="x" src="/a.png" />`},
+		{Name: "Switch", Tag: Input(Switch("email").Case("email", Type("email")).Default(Type("text"))), Exp: `<input type="email" />`},
+		{Name: "When", Tag: Input(When(func(ctx context.Context) bool { return true }, func() Node { return Required() })), Exp: `<input required />`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Tag, tc.Exp)
+		})
+	}
+}
+
+func TestIfElseWithTagInSelfClosingTag(t *testing.T) {
+	var err error
+	assertNotPanic(t, func() {
+		err = Input(If(true, Div())).Render(context.Background(), &failingWriter{})
+	})
+	if err == nil {
+		t.Errorf("Expected an error, got nil")
+	}
+}
+
+type ctxFlagKey struct{}
+
+func TestWhenIsLazy(t *testing.T) {
+	built := 0
+	expensive := func() Node {
+		built++
+		return Text("expensive")
+	}
+	isSet := func(ctx context.Context) bool { return ctx.Value(ctxFlagKey{}) != nil }
+
+	assertEqual(t, Div(When(isSet, expensive)), "<div></div>")
+	if built != 0 {
+		t.Errorf("Expected the node not to be built, got built %d times", built)
+	}
+
+	assertEqual(t, Div(When(isSet, expensive).Else(func() Node { return Text("cheap") })), "<div>cheap</div>")
+	if built != 0 {
+		t.Errorf("Expected the node not to be built, got built %d times", built)
+	}
+
+	ctx := context.WithValue(context.Background(), ctxFlagKey{}, true)
+	strbuf := &strings.Builder{}
+	if err := Div(When(isSet, expensive)).Render(ctx, strbuf); err != nil {
+		t.Fatalf("Error rendering: %s", err)
+	}
+	if strbuf.String() != "<div>expensive</div>" {
+		t.Errorf("Expected <div>expensive</div>, got %s", strbuf.String())
+	}
+	if built != 1 {
+		t.Errorf("Expected the node to be built once, got built %d times", built)
+	}
+}
@@ -0,0 +1,115 @@
+package yahw
+
+import (
+	"context"
+	"strings"
+)
+
+// Key returns the name of the attribute.
+func (a Attribute) Key() string { return a.key }
+
+// Value returns the value of the attribute as it was given, before URLs are
+// sanitized.
+func (a Attribute) Value() string { return a.value }
+
+// TagName returns the name of the tag.
+func (t CommonTag) TagName() string { return t.tagName }
+
+// Attrs returns the attributes of the tag merged like when it's rendered,
+// e.g. with all classes in a single attribute. Components among the children
+// are resolved with an empty context, use Resolve or Walk for the context a
+// page is rendered with.
+func (t CommonTag) Attrs() []Attribute { return inspectAttrs(context.Background(), t) }
+
+// Attr returns the value of the attribute key, and whether the tag has it.
+func (t CommonTag) Attr(key string) (string, bool) { return findAttr(t.Attrs(), key) }
+
+// Children returns the child nodes of the tag that aren't attributes, with
+// components and fragments resolved like in Attrs.
+func (t CommonTag) Children() []Renderable {
+	children := []Renderable{}
+	for _, r := range unwrapNodes(context.Background(), t.children) {
+		if _, ok := r.(attrable); ok || r == nil {
+			continue
+		}
+		children = append(children, r)
+	}
+	return children
+}
+
+// TagName returns the name of the tag.
+func (t SelfClosingTag) TagName() string { return t.tagName }
+
+// Attrs returns the attributes of the tag merged like when it's rendered.
+func (t SelfClosingTag) Attrs() []Attribute { return inspectAttrs(context.Background(), t) }
+
+// Attr returns the value of the attribute key, and whether the tag has it.
+func (t SelfClosingTag) Attr(key string) (string, bool) { return findAttr(t.Attrs(), key) }
+
+// inspectAttrs returns the merged attributes of the element r. Attributes
+// that can't be merged are left out; rendering reports them as errors.
+func inspectAttrs(ctx context.Context, r Renderable) []Attribute {
+	var attrs []attrable
+	switch t := r.(type) {
+	case CommonTag:
+		for _, child := range unwrapNodes(ctx, t.children) {
+			if attr, ok := child.(attrable); ok {
+				attrs = append(attrs, attr)
+			}
+		}
+	case SelfClosingTag:
+		attrs = t.attrs
+	}
+
+	merged, err := mergeAttrs(ctx, attrs)
+	if err != nil {
+		return nil
+	}
+	res := make([]Attribute, 0, len(merged))
+	for _, attr := range merged {
+		if named, ok := attr.(namedAttr); ok && named.attrKey() != "" {
+			res = append(res, Attribute{key: named.attrKey(), value: named.attrValue()})
+		}
+	}
+	return res
+}
+
+func findAttr(attrs []Attribute, key string) (string, bool) {
+	for _, attr := range attrs {
+		if strings.EqualFold(attr.key, key) {
+			return attr.value, true
+		}
+	}
+	return "", false
+}
+
+// Walk resolves node like Resolve and calls visit for every node in the
+// resolved tree except attributes, in document order. If visit returns false,
+// the children of the node are skipped.
+func Walk(ctx context.Context, node Node, visit func(r Renderable) bool) error {
+	tree, err := Resolve(ctx, node)
+	if err != nil {
+		return err
+	}
+	tree.Walk(visit)
+	return nil
+}
+
+// Walk calls visit for every node in t except attributes, in document order.
+// If visit returns false, the children of the node are skipped.
+func (t *Tree) Walk(visit func(r Renderable) bool) {
+	walkTreeUntil(t.root, visit)
+}
+
+func walkTreeUntil(r Renderable, visit func(r Renderable) bool) {
+	if r == nil || !visit(r) {
+		return
+	}
+	for _, child := range childrenOf(r) {
+		if c := renderableOf(child); c != nil {
+			if _, ok := c.(attrable); !ok {
+				walkTreeUntil(c, visit)
+			}
+		}
+	}
+}
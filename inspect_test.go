@@ -0,0 +1,74 @@
+package yahw
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTagAccessors(t *testing.T) {
+	div := Div(ID("a"), Classes("b"), AttrSlice{Classes("c"), If(true, Lang("en"))}, Text("x"), Fragment{Span(), nodeFunc(func(ctx context.Context) Renderable { return P() })})
+
+	if div.TagName() != "div" {
+		t.Errorf("Expected div, got %s", div.TagName())
+	}
+	var attrs []string
+	for _, attr := range div.Attrs() {
+		attrs = append(attrs, attr.Key()+"="+attr.Value())
+	}
+	if got := strings.Join(attrs, " "); got != "id=a class=b c lang=en" {
+		t.Errorf("Expected id=a class=b c lang=en, got %s", got)
+	}
+	if v, ok := div.Attr("CLASS"); !ok || v != "b c" {
+		t.Errorf("Expected class b c, got %q, %t", v, ok)
+	}
+	if _, ok := div.Attr("title"); ok {
+		t.Errorf("Expected no title")
+	}
+
+	children := div.Children()
+	if len(children) != 3 {
+		t.Fatalf("Expected 3 children, got %#v", children)
+	}
+	assertEqual(t, children[0], "x")
+	assertEqual(t, children[1], "<span></span>")
+	assertEqual(t, children[2], "<p></p>")
+
+	img := Img(Src("a.png"), Alt("A"))
+	if img.TagName() != "img" {
+		t.Errorf("Expected img, got %s", img.TagName())
+	}
+	if v, ok := img.Attr("alt"); !ok || v != "A" {
+		t.Errorf("Expected alt A, got %q, %t", v, ok)
+	}
+	if n := len(Br().Attrs()); n != 0 {
+		t.Errorf("Expected no attributes, got %d", n)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	page := Div(ID("a"), Ul(Li(Text("1")), Li(Text("2"))), nodeFunc(func(ctx context.Context) Renderable { return P(Text("3")) }))
+
+	var visited []string
+	err := Walk(context.Background(), page, func(r Renderable) bool {
+		switch n := r.(type) {
+		case CommonTag:
+			visited = append(visited, n.TagName())
+			return n.TagName() != "p"
+		case Text:
+			visited = append(visited, string(n))
+		}
+		return true
+	})
+	if err != nil {
+		t.Fatalf("Error walking: %s", err)
+	}
+	if got := strings.Join(visited, " "); got != "div ul li 1 li 2 p" {
+		t.Errorf("Expected div ul li 1 li 2 p, got %s", got)
+	}
+
+	err = Walk(context.Background(), Div(Span(), CommonTag{tagName: "b c"}), func(r Renderable) bool { return true })
+	if _, ok := err.(*RenderError); !ok {
+		t.Errorf("Expected a *RenderError, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package yahw
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ForEach calls fn for every item and collects the returned nodes. Like any
+// Nodes, the result is flattened into the parent tag, so attributes returned by
+// fn are applied to it. Nil nodes are skipped.
+func ForEach[T any](items []T, fn func(int, T) Node) Nodes {
+	nodes := make(Nodes, 0, len(items))
+	for i, item := range items {
+		nodes = append(nodes, fn(i, item))
+	}
+	return nodes
+}
+
+// Range calls fn for every number from 0 to n-1 and collects the returned
+// nodes, see ForEach.
+func Range(n int, fn func(int) Node) Nodes {
+	if n < 0 {
+		n = 0
+	}
+	nodes := make(Nodes, 0, n)
+	for i := 0; i < n; i++ {
+		nodes = append(nodes, fn(i))
+	}
+	return nodes
+}
+
+// ForEachSorted calls fn for every entry of m in the order of its keys, so the
+// output doesn't change between renders, and collects the returned nodes, see
+// ForEach.
+func ForEachSorted[K cmp.Ordered, V any](m map[K]V, fn func(K, V) Node) Nodes {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	nodes := make(Nodes, 0, len(keys))
+	for _, k := range keys {
+		nodes = append(nodes, fn(k, m[k]))
+	}
+	return nodes
+}
@@ -0,0 +1,53 @@
+package yahw
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestForEach(t *testing.T) {
+	items := []string{"a", "b", "<c>"}
+
+	assertEqual(t, Ul(ForEach(items, func(i int, item string) Node {
+		return Li(Text(strconv.Itoa(i)+":"), Text(item))
+	})), "<ul><li>0:a</li><li>1:b</li><li>2:&lt;c&gt;</li></ul>")
+
+	assertEqual(t, Ul(ForEach([]string{}, func(i int, item string) Node { return Li() })), "<ul></ul>")
+}
+
+func TestForEachMixedWithOtherChildren(t *testing.T) {
+	items := []int{1, 2, 3}
+
+	assertEqual(t, Ul(
+		ID("list"),
+		Li(Text("first")),
+		ForEach(items, func(i int, item int) Node {
+			if item == 2 {
+				return nil
+			}
+			return Li(Text(strconv.Itoa(item)))
+		}),
+		ForEach(items, func(i int, item int) Node {
+			return Classes("c" + strconv.Itoa(item))
+		}),
+		Li(Text("last")),
+	), `<ul id="list" class="c1 c2 c3"><li>first</li><li>1</li><li>3</li><li>last</li></ul>`)
+}
+
+func TestRange(t *testing.T) {
+	assertEqual(t, Tr(Range(3, func(i int) Node {
+		return Td(Text(strconv.Itoa(i)))
+	})), "<tr><td>0</td><td>1</td><td>2</td></tr>")
+
+	assertEqual(t, Tr(Range(-1, func(i int) Node { return Td() })), "<tr></tr>")
+}
+
+func TestForEachSorted(t *testing.T) {
+	m := map[string]int{"c": 3, "a": 1, "b": 2, "d": 4, "e": 5}
+
+	for i := 0; i < 20; i++ {
+		assertEqual(t, Dl(ForEachSorted(m, func(k string, v int) Node {
+			return Nodes{Dt(Text(k)), Dd(Text(strconv.Itoa(v)))}
+		})), "<dl><dt>a</dt><dd>1</dd><dt>b</dt><dd>2</dd><dt>c</dt><dd>3</dd><dt>d</dt><dd>4</dd><dt>e</dt><dd>5</dd></dl>")
+	}
+}
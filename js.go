@@ -0,0 +1,83 @@
+package yahw
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// JS is a JavaScript expression, as used in event handler attributes. It's
+// written as is, so it must not contain user input. Use JSCall to pass Go
+// values to JavaScript.
+type JS string
+
+// jsHandler is accepted by event handler attributes such as OnClick.
+type jsHandler interface {
+	string | JS
+}
+
+func eventAttr[T jsHandler](event string, handler T) Attribute {
+	return BuildAttr(event, string(handler))
+}
+
+func isValidJSFuncName(fn string) bool {
+	for _, part := range strings.Split(fn, ".") {
+		if len(part) == 0 {
+			return false
+		}
+		for i, c := range part {
+			switch {
+			case 'a' <= c && c <= 'z':
+			case 'A' <= c && c <= 'Z':
+			case c == '_' || c == '$':
+			case '0' <= c && c <= '9' && i > 0:
+			default:
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// JSCall builds a call of the function fn, e.g. "app.save", with args encoded
+// as JSON. Arguments of type JS are written as is, so JSCall("f", JS("event"))
+// passes the event to f. It panics on an invalid function name or an
+// argument JSON can't encode, like NaN or a channel; use TryJSCall for
+// arguments that come from users.
+func JSCall(fn string, args ...any) JS {
+	js, err := TryJSCall(fn, args...)
+	if err != nil {
+		panic(err)
+	}
+	return js
+}
+
+// TryJSCall is like JSCall, but returns an error for an invalid function name
+// or argument.
+func TryJSCall(fn string, args ...any) (JS, error) {
+	if !isValidJSFuncName(fn) {
+		return "", fmt.Errorf("invalid JS function name: %q", fn)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fn)
+	sb.WriteString("(")
+	for i, arg := range args {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		if js, ok := arg.(JS); ok {
+			sb.WriteString(string(js))
+			continue
+		}
+		// json.Marshal escapes <, > and & as well as U+2028 and U+2029, so
+		// the result is safe to put into both attributes and script tags.
+		bz, err := json.Marshal(arg)
+		if err != nil {
+			return "", fmt.Errorf("invalid JS argument %d: %w", i, err)
+		}
+		sb.Write(bz)
+	}
+	sb.WriteString(")")
+	return JS(sb.String()), nil
+}
@@ -0,0 +1,84 @@
+package yahw
+
+import (
+	"math"
+	"testing"
+)
+
+func TestJSCall(t *testing.T) {
+	tt := []struct {
+		Name string
+		Call JS
+		Exp  string
+	}{
+		{Name: "No arguments", Call: JSCall("run"), Exp: `run()`},
+		{Name: "Namespaced", Call: JSCall("app.$store.save", 1), Exp: `app.$store.save(1)`},
+		{Name: "Values", Call: JSCall("f", 1, 2.5, true, nil, "a"), Exp: `f(1,2.5,true,null,"a")`},
+		{Name: "Structs", Call: JSCall("f", struct{ ID int }{ID: 3}, []string{"x"}), Exp: `f({"ID":3},["x"])`},
+		{Name: "Quotes", Call: JSCall("f", `");alert("x`), Exp: `f("\");alert(\"x")`},
+		{Name: "Markup", Call: JSCall("f", "</script>&"), Exp: `f("\u003c/script\u003e\u0026")`},
+		{Name: "Line separators", Call: JSCall("f", "a\u2028b"), Exp: `f("a\u2028b")`},
+		{Name: "JS argument", Call: JSCall("f", JS("event"), "x"), Exp: `f(event,"x")`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			if string(tc.Call) != tc.Exp {
+				t.Errorf("Expected %s, got %s", tc.Exp, tc.Call)
+			}
+		})
+	}
+}
+
+func TestJSCallInvalidFunction(t *testing.T) {
+	tt := []struct {
+		Name string
+		Fn   string
+	}{
+		{Name: "Empty", Fn: ""},
+		{Name: "Starts with digit", Fn: "1f"},
+		{Name: "Call", Fn: "alert(1);f"},
+		{Name: "Trailing dot", Fn: "app."},
+		{Name: "Space", Fn: "a b"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertPanic(t, func() { JSCall(tc.Fn) })
+		})
+	}
+
+	assertPanic(t, func() { JSCall("f", make(chan int)) })
+}
+
+func TestTryJSCall(t *testing.T) {
+	js, err := TryJSCall("f", 1, "a")
+	if err != nil || js != `f(1,"a")` {
+		t.Errorf("Expected f(1,\"a\"), got %s, %v", js, err)
+	}
+
+	for _, tc := range []struct {
+		Name string
+		Fn   string
+		Args []any
+	}{
+		{Name: "Invalid function", Fn: "1f"},
+		{Name: "NaN", Fn: "f", Args: []any{1, math.NaN()}},
+		{Name: "Infinity", Fn: "f", Args: []any{math.Inf(1)}},
+		{Name: "Channel", Fn: "f", Args: []any{make(chan int)}},
+		{Name: "Func in a struct", Fn: "f", Args: []any{struct{ F func() }{F: func() {}}}},
+	} {
+		t.Run(tc.Name, func(t *testing.T) {
+			if js, err := TryJSCall(tc.Fn, tc.Args...); err == nil {
+				t.Errorf("Expected an error, got %s", js)
+			}
+		})
+	}
+	assertPanic(t, func() { JSCall("f", math.NaN()) })
+}
+
+func TestEventHandlers(t *testing.T) {
+	assertEqual(t, OnClick("save()"), `onclick="save()"`)
+	assertEqual(t, OnClick(JSCall("save", `a"b`)), `onclick="save(&#34;a\&#34;b&#34;)"`)
+	assertEqual(t, OnSubmit(JSCall("submit", JS("event"), 42)), `onsubmit="submit(event,42)"`)
+}
@@ -0,0 +1,306 @@
+package yahw
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// MergePolicy decides how an attribute given more than once on the same tag
+// is rendered.
+type MergePolicy int
+
+const (
+	// LastWins keeps only the last value.
+	LastWins MergePolicy = iota
+	// MergeTokens joins space separated tokens, dropping duplicates.
+	MergeTokens
+	// MergeDeclarations joins CSS declarations. A property set more than
+	// once keeps its last value.
+	MergeDeclarations
+)
+
+var defaultMergePolicies = map[string]MergePolicy{
+	"class":            MergeTokens,
+	"rel":              MergeTokens,
+	"aria-describedby": MergeTokens,
+	"style":            MergeDeclarations,
+}
+
+// AttrConflictError is returned in strict mode when a LastWins attribute is
+// given different values on the same tag.
+type AttrConflictError struct {
+	Key    string
+	Values []string
+}
+
+func (e *AttrConflictError) Error() string {
+	return fmt.Sprintf("conflicting values for attribute %s: %q", e.Key, e.Values)
+}
+
+type attrPolicies struct {
+	policies map[string]MergePolicy
+	strict   bool
+}
+
+type attrPoliciesKey struct{}
+
+func attrPoliciesFrom(ctx context.Context) attrPolicies {
+	if p, ok := ctx.Value(attrPoliciesKey{}).(attrPolicies); ok {
+		return p
+	}
+	return attrPolicies{policies: defaultMergePolicies}
+}
+
+// WithMergePolicy returns a context in which duplicates of the attribute key
+// are merged according to policy.
+func WithMergePolicy(ctx context.Context, key string, policy MergePolicy) context.Context {
+	p := attrPoliciesFrom(ctx)
+	policies := make(map[string]MergePolicy, len(p.policies)+1)
+	for k, v := range p.policies {
+		policies[k] = v
+	}
+	policies[strings.ToLower(key)] = policy
+	p.policies = policies
+	return context.WithValue(ctx, attrPoliciesKey{}, p)
+}
+
+// WithStrictAttrs returns a context in which rendering fails with an
+// *AttrConflictError instead of silently dropping LastWins attributes that
+// were given different values.
+func WithStrictAttrs(ctx context.Context) context.Context {
+	p := attrPoliciesFrom(ctx)
+	p.strict = true
+	return context.WithValue(ctx, attrPoliciesKey{}, p)
+}
+
+func (p attrPolicies) policy(key string) MergePolicy {
+	return p.policies[key]
+}
+
+// namedAttr is implemented by attributes that can be merged with others of
+// the same name.
+type namedAttr interface {
+	attrable
+	attrKey() string
+	attrValue() string
+}
+
+func (a Attribute) attrKey() string        { return a.key }
+func (a Attribute) attrValue() string      { return a.value }
+func (a NoValAttribute) attrKey() string   { return a.key }
+func (a NoValAttribute) attrValue() string { return "" }
+func (a URLAttribute) attrKey() string     { return a.key }
+func (a URLAttribute) attrValue() string   { return a.value }
+func (c Classes) attrKey() string          { return "class" }
+func (c Classes) attrValue() string        { return string(c) }
+func (c ClassesMap) attrKey() string       { return "class" }
+func (c ClassesMap) attrValue() string     { return c.extract() }
+
+// flattenAttrs returns attrs with nested AttrSlices expanded and conditionals
+// like IfElse replaced by the attributes they select.
+func flattenAttrs(ctx context.Context, attrs AttrSlice) (AttrSlice, error) {
+	flat := AttrSlice{}
+	for _, attr := range attrs {
+		switch a := attr.(type) {
+		case nil:
+		case AttrSlice:
+			nested, err := flattenAttrs(ctx, a)
+			if err != nil {
+				return nil, err
+			}
+			flat = append(flat, nested...)
+		case evaluator:
+			eval := a.evaluate(ctx)
+			if eval == nil {
+				continue
+			}
+			selected, ok := eval.(attrable)
+			if !ok {
+				return nil, fmt.Errorf("%w: %T among attributes", ErrInvalidNode, eval)
+			}
+			nested, err := flattenAttrs(ctx, AttrSlice{selected})
+			if err != nil {
+				return nil, err
+			}
+			flat = append(flat, nested...)
+		default:
+			flat = append(flat, a)
+		}
+	}
+	return flat, nil
+}
+
+// mergeAttrs combines attributes with the same name according to the merge
+// policies in ctx. Merged attributes are placed where the first of them was.
+func mergeAttrs(ctx context.Context, attrs AttrSlice) (AttrSlice, error) {
+	merged := AttrSlice{}
+	positions := map[string]int{}
+	groups := map[string][]namedAttr{}
+	flat, err := flattenAttrs(ctx, attrs)
+	if err != nil {
+		return nil, err
+	}
+	for _, attr := range flat {
+		if s, ok := attr.(Styles); ok {
+			if err := s.validate(); err != nil {
+				return nil, err
+			}
+		}
+		named, ok := attr.(namedAttr)
+		if !ok {
+			merged = append(merged, attr)
+			continue
+		}
+
+		key := strings.ToLower(named.attrKey())
+		if _, ok := positions[key]; !ok {
+			positions[key] = len(merged)
+			merged = append(merged, nil)
+		}
+		groups[key] = append(groups[key], named)
+	}
+
+	policies := attrPoliciesFrom(ctx)
+	for key, group := range groups {
+		// Classes are always merged, which also drops duplicates.
+		if len(group) == 1 && key != "class" {
+			merged[positions[key]] = group[0]
+			continue
+		}
+
+		var attr attrable
+		switch policies.policy(key) {
+		case MergeTokens:
+			var err error
+			attr, err = mergeTokens(key, group)
+			if err != nil {
+				return nil, err
+			}
+		case MergeDeclarations:
+			var err error
+			attr, err = mergeDeclarations(key, group)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			if policies.strict {
+				if err := checkConflict(key, group); err != nil {
+					return nil, err
+				}
+			}
+			attr = group[len(group)-1]
+		}
+		merged[positions[key]] = attr
+	}
+
+	return merged, nil
+}
+
+func checkConflict(key string, group []namedAttr) error {
+	values := []string{}
+	for _, attr := range group {
+		if !slices.Contains(values, attr.attrValue()) {
+			values = append(values, attr.attrValue())
+		}
+	}
+	if len(values) > 1 {
+		return &AttrConflictError{Key: key, Values: values}
+	}
+	return nil
+}
+
+func mergeTokens(key string, group []namedAttr) (attrable, error) {
+	if key == "class" {
+		clss := AttrSlice{}
+		for _, attr := range group {
+			clss = append(clss, attr)
+		}
+		merged, err := mergeClasses(clss)
+		if err != nil {
+			return nil, err
+		}
+		return Classes(strings.Join(extractClasses(string(merged)), " ")), nil
+	}
+
+	values := make([]string, len(group))
+	for i, attr := range group {
+		values[i] = attr.attrValue()
+	}
+	return Attribute{key: key, value: strings.Join(extractClasses(strings.Join(values, " ")), " ")}, nil
+}
+
+func mergeDeclarations(key string, group []namedAttr) (attrable, error) {
+	props := []string{}
+	decls := map[string]string{}
+	for _, attr := range group {
+		for _, decl := range splitDeclarations(attr.attrValue()) {
+			decl = strings.TrimSpace(decl)
+			if decl == "" {
+				continue
+			}
+			prop, _, _ := strings.Cut(decl, ":")
+			prop = strings.ToLower(strings.TrimSpace(prop))
+			if _, ok := decls[prop]; !ok {
+				props = append(props, prop)
+			}
+			decls[prop] = decl
+		}
+	}
+
+	res := make([]string, len(props))
+	for i, prop := range props {
+		res[i] = decls[prop] + ";"
+	}
+	return Attribute{key: key, value: strings.Join(res, " ")}, nil
+}
+
+// splitDeclarations splits CSS declarations at semicolons that aren't within
+// a string or parentheses, e.g. in url("data:image/png;base64,...").
+func splitDeclarations(css string) []string {
+	var decls []string
+	var quote byte
+	depth, start := 0, 0
+	for i := 0; i < len(css); i++ {
+		switch c := css[i]; {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')' && depth > 0:
+			depth--
+		case c == ';' && depth == 0:
+			decls = append(decls, css[start:i])
+			start = i + 1
+		}
+	}
+	return append(decls, css[start:])
+}
+
+// renderAttrs merges attrs and renders them, each preceded by a space.
+func renderAttrs(ctx context.Context, w io.Writer, attrs AttrSlice) error {
+	merged, err := mergeAttrs(ctx, attrs)
+	if err != nil {
+		return err
+	}
+
+	for _, attr := range merged {
+		_, err := io.WriteString(w, " ")
+		if err != nil {
+			return err
+		}
+		err = attr.Render(ctx, w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package yahw
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMergingDuplicateAttributes(t *testing.T) {
+	div := TagBuilder("div")
+	input := SelfClosingTagBuilder("input")
+
+	tt := []struct {
+		Name string
+		Tag  Renderable
+		Exp  string
+	}{
+		{Name: "Last wins", Tag: div(ID("a"), TitleAttr("t"), ID("b")), Exp: `<div id="b" title="t"></div>`},
+		{Name: "Last wins self-closing", Tag: input(Name("a"), Value("x"), Name("b")), Exp: `<input name="b" value="x" />`},
+		{Name: "Different case", Tag: div(BuildAttr("ID", "a"), ID("b")), Exp: `<div id="b"></div>`},
+		{Name: "Boolean", Tag: input(Disabled(), Disabled()), Exp: `<input disabled />`},
+		{Name: "URL", Tag: div(Href("/a"), Href("/b")), Exp: `<div href="/b"></div>`},
+		{Name: "Rel", Tag: div(Rel("noopener"), Rel("noreferrer noopener")), Exp: `<div rel="noopener noreferrer"></div>`},
+		{Name: "Aria describedby", Tag: div(Aria("describedby", "a"), ID("x"), Aria("describedby", "b")), Exp: `<div aria-describedby="a b" id="x"></div>`},
+		{Name: "Style", Tag: div(StyleAttr("color: red; margin: 0"), StyleAttr("padding: 1px;color:blue")), Exp: `<div style="color:blue; margin: 0; padding: 1px;"></div>`},
+		{Name: "Semicolons in values", Tag: div(StyleAttr(`background:url("data:image/png;base64,AAA")`), StyleAttr("font-family:'a;b', serif; color:red")), Exp: `<div style="background:url(&#34;data:image/png;base64,AAA&#34;); font-family:&#39;a;b&#39;, serif; color:red;"></div>`},
+		{Name: "Semicolons in parentheses", Tag: div(StyleAttr("background:url(a;b.png)"), StyleAttr("color:red")), Exp: `<div style="background:url(a;b.png); color:red;"></div>`},
+		{Name: "Single style is kept", Tag: div(StyleAttr("color:red")), Exp: `<div style="color:red"></div>`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Tag, tc.Exp)
+		})
+	}
+}
+
+func TestCustomMergePolicy(t *testing.T) {
+	ctx := WithMergePolicy(context.Background(), "data-tags", MergeTokens)
+	ctx = WithMergePolicy(ctx, "rel", LastWins)
+
+	strbuf := &strings.Builder{}
+	err := Div(DataAttr("tags", "a b"), DataAttr("tags", "b c"), Rel("a"), Rel("b")).Render(ctx, strbuf)
+	if err != nil {
+		t.Fatalf("Error rendering: %s", err)
+	}
+	if exp := `<div data-tags="a b c" rel="b"></div>`; strbuf.String() != exp {
+		t.Errorf("Expected %s, got %s", exp, strbuf.String())
+	}
+
+	// The parent context is left untouched.
+	assertEqual(t, Div(Rel("a"), Rel("b")), `<div rel="a b"></div>`)
+}
+
+func TestStrictAttrs(t *testing.T) {
+	ctx := WithStrictAttrs(context.Background())
+
+	tt := []struct {
+		Name     string
+		Tag      Renderable
+		Conflict bool
+	}{
+		{Name: "Conflicting ids", Tag: Div(ID("a"), ID("b")), Conflict: true},
+		{Name: "Conflicting self-closing", Tag: Input(Type("text"), Type("email")), Conflict: true},
+		{Name: "Conflict in child", Tag: Div(Span(ID("a"), ID("b"))), Conflict: true},
+		{Name: "Same value", Tag: Div(ID("a"), ID("a"))},
+		{Name: "Merged classes", Tag: Div(Classes("a"), Classes("b"))},
+		{Name: "Merged styles", Tag: Div(StyleAttr("color: red"), StyleAttr("color: blue"))},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			err := tc.Tag.Render(ctx, &strings.Builder{})
+			var conflict *AttrConflictError
+			if tc.Conflict != errors.As(err, &conflict) {
+				t.Errorf("Expected conflict: %v, got %v", tc.Conflict, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package yahw
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// Minify makes RenderTo produce the shortest markup parsing into the same
+// document: optional end tags like </li> and </p> are left out, attribute
+// values are only quoted when they have to be, empty attributes are written
+// without a value, void elements without the closing slash, and whitespace
+// within text is collapsed except in whitespace-sensitive elements like pre.
+// With XHTMLSyntax, only whitespace is collapsed.
+func Minify() RenderOption {
+	return func(c *renderConfig) {
+		c.minify = true
+	}
+}
+
+type minifyKey struct{}
+
+// minifyState is what a minified node knows about where it's rendered.
+type minifyState struct {
+	// parent is the name of the enclosing element.
+	parent string
+	// next is the name of the following sibling element. It's empty if the
+	// sibling isn't an element.
+	next string
+	// last is set if nothing follows the node within its parent.
+	last bool
+	// preformatted is set within elements whose whitespace is rendered as is.
+	preformatted bool
+}
+
+func withMinify(ctx context.Context, m minifyState) context.Context {
+	return context.WithValue(ctx, minifyKey{}, m)
+}
+
+// minifyOf returns the current minify state. It reports false if output
+// isn't minified.
+func minifyOf(ctx context.Context) (minifyState, bool) {
+	m, ok := ctx.Value(minifyKey{}).(minifyState)
+	return m, ok
+}
+
+// minifiesMarkup reports whether markup is minified. XHTML is only minified
+// by collapsing whitespace, as it has to stay well-formed.
+func minifiesMarkup(ctx context.Context) bool {
+	_, ok := minifyOf(ctx)
+	return ok && syntaxOf(ctx) != XHTMLSyntax
+}
+
+// child returns the state at the end of the children of tagName.
+func (m minifyState) child(tagName string) minifyState {
+	tagName = strings.ToLower(tagName)
+	return minifyState{
+		parent:       tagName,
+		last:         true,
+		preformatted: m.preformatted || preformattedElements[tagName],
+	}
+}
+
+// followed returns m for nodes followed by something that isn't an element,
+// such as a comment.
+func (m minifyState) followed() minifyState {
+	m.next, m.last = "", false
+	return m
+}
+
+// minifyStateAt returns the state of children[idx], given the state m at the
+// end of children. Fragments and conditional comments pass their own state,
+// so their last child takes their place within the parent.
+func minifyStateAt[T Renderable](m minifyState, children []T, idx int) minifyState {
+	for _, next := range children[idx+1:] {
+		switch next := Renderable(next).(type) {
+		case nil:
+			continue
+		case CommonTag:
+			m.next, m.last = strings.ToLower(next.tagName), false
+		case SelfClosingTag:
+			m.next, m.last = strings.ToLower(next.tagName), false
+		default:
+			m = m.followed()
+		}
+		return m
+	}
+	return m
+}
+
+// endTagClosers lists the elements whose start tag implies the end tag of
+// the element before them.
+var endTagClosers = map[string][]string{
+	"li":       {"li"},
+	"dt":       {"dt", "dd"},
+	"dd":       {"dt", "dd"},
+	"rt":       {"rt", "rp"},
+	"rp":       {"rt", "rp"},
+	"optgroup": {"optgroup"},
+	"option":   {"option", "optgroup"},
+	"thead":    {"tbody", "tfoot"},
+	"tbody":    {"tbody", "tfoot"},
+	"tr":       {"tr"},
+	"td":       {"td", "th"},
+	"th":       {"td", "th"},
+	"p": {
+		"address", "article", "aside", "blockquote", "details", "dialog", "div",
+		"dl", "fieldset", "figcaption", "figure", "footer", "form", "h1", "h2",
+		"h3", "h4", "h5", "h6", "header", "hgroup", "hr", "main", "menu", "nav",
+		"ol", "p", "pre", "section", "ul",
+	},
+}
+
+// endTagParents lists the elements whose end tag implies the end tag of
+// their last child.
+var endTagParents = map[string][]string{
+	"li":       {"ul", "ol", "menu"},
+	"dd":       {"dl", "div"},
+	"rt":       {"ruby"},
+	"rp":       {"ruby"},
+	"optgroup": {"select"},
+	"option":   {"select", "datalist", "optgroup"},
+	"tbody":    {"table"},
+	"tfoot":    {"table"},
+	"tr":       {"thead", "tbody", "tfoot", "table"},
+	"td":       {"tr"},
+	"th":       {"tr"},
+	"p": {
+		"address", "article", "aside", "blockquote", "body", "caption",
+		"details", "dialog", "div", "dd", "dl", "dt", "fieldset", "figcaption",
+		"figure", "footer", "form", "h1", "h2", "h3", "h4", "h5", "h6",
+		"header", "hgroup", "li", "main", "menu", "nav", "ol", "section",
+		"summary", "td", "th", "template", "ul",
+	},
+}
+
+// omitsEndTag reports whether the end tag of tagName can be left out, given
+// what follows it.
+func omitsEndTag(tagName string, m minifyState) bool {
+	tagName = strings.ToLower(tagName)
+	if m.last {
+		return slices.Contains(endTagParents[tagName], m.parent)
+	}
+	return m.next != "" && slices.Contains(endTagClosers[tagName], m.next)
+}
+
+// unquotable reports whether an attribute value can be written without
+// quotes.
+func unquotable(value string) bool {
+	return value != "" && !strings.ContainsAny(value, " \t\n\f\r\"'=<>`")
+}
+
+// collapseSpace replaces every run of whitespace in s with a single space.
+func collapseSpace(s string) string {
+	if !strings.ContainsAny(s, " \t\n\f\r") {
+		return s
+	}
+	var sb strings.Builder
+	sb.Grow(len(s))
+	space := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case ' ', '\t', '\n', '\f', '\r':
+			if !space {
+				sb.WriteByte(' ')
+			}
+			space = true
+		default:
+			sb.WriteByte(c)
+			space = false
+		}
+	}
+	return sb.String()
+}
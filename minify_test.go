@@ -0,0 +1,188 @@
+package yahw
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+func TestMinify(t *testing.T) {
+	tt := []struct {
+		Name string
+		Node Node
+		Exp  string
+	}{
+		{
+			Name: "List items",
+			Node: Ul(Li(Text("a")), Li(Text("b"))),
+			Exp:  "<ul><li>a<li>b</ul>",
+		},
+		{
+			Name: "Paragraphs",
+			Node: Div(P(Text("a")), P(Text("b")), Span(), P(Text("c"))),
+			Exp:  "<div><p>a<p>b</p><span></span><p>c</div>",
+		},
+		{
+			Name: "Paragraph within inline parent",
+			Node: A(P(Text("a"))),
+			Exp:  "<a><p>a</p></a>",
+		},
+		{
+			Name: "Paragraph followed by text",
+			Node: Div(P(Text("a")), Text("b")),
+			Exp:  "<div><p>a</p>b</div>",
+		},
+		{
+			Name: "Table",
+			Node: Table(Tbody(Tr(Td(Text("a")), Th(Text("b"))), Tr(Td(Text("c"))))),
+			Exp:  "<table><tbody><tr><td>a<th>b<tr><td>c</table>",
+		},
+		{
+			Name: "Attributes",
+			Node: Input(Type("text"), Value("a b"), Name(""), Disabled(), ID("x&y")),
+			Exp:  `<input type=text value="a b" name disabled id=x&amp;y>`,
+		},
+		{
+			Name: "Whitespace",
+			Node: Div(Text("  a \n\t b  "), Pre(Text("  a \n b")), Textarea(Text(" x  y "))),
+			Exp:  "<div> a b <pre>  a \n b</pre><textarea> x  y </textarea></div>",
+		},
+		{
+			Name: "Script",
+			Node: Script(Text("if (a  <  b) {}")),
+			Exp:  "<script>if (a  <  b) {}</script>",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			strbuf := &strings.Builder{}
+			err := RenderTo(context.Background(), strbuf, tc.Node, Minify())
+			if err != nil {
+				t.Fatalf("Error rendering: %s", err)
+			}
+			if strbuf.String() != tc.Exp {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tc.Exp, strbuf.String())
+			}
+		})
+	}
+}
+
+func TestMinifyKeepsDocument(t *testing.T) {
+	tt := []struct {
+		Name string
+		Node Node
+	}{
+		{
+			Name: "Lists",
+			Node: Body(
+				Ul(Li(P(Text("a")), P(Text("b"))), Li(Ol(Li(Text("c"))))),
+				Dl(Dt(Text("a")), Dd(Text("b")), Dt(Text("c")), Dd(P(Text("d")))),
+				Dl(Div(Dt(Text("a")), Dd(Text("b")))),
+			),
+		},
+		{
+			Name: "Paragraphs",
+			Node: Body(
+				P(Text("a")), Div(P(Text("b")), Hr(), P(Text("c"))),
+				Section(P(Text("d")), Span(Text("e")), P(Strong(Text("f")))),
+				Span(P(Text("g"))), Ins(P(Text("h"))), P(Text("i")),
+			),
+		},
+		{
+			Name: "Tables",
+			Node: Body(Table(
+				Thead(Tr(Th(Text("a")), Th(Text("b")))),
+				Tbody(Tr(Td(Text("c")), Td(P(Text("d"))))),
+				Tfoot(Tr(Td(ColSpan("2"), Text("e")))),
+			)),
+		},
+		{
+			Name: "Tag slices",
+			Node: Body(
+				Div(TagSlice{P(Text("a")), Span(Text("b"))}),
+				Div(TagSlice{P(Text("a"))}, Span(Text("b"))),
+				Ul(TagSlice{Li(Text("a")), Li(Text("b"))}, Li(Text("c"))),
+				Div(P(Text("a")), TagSlice{Span(Text("b")), P(Text("c"))}),
+			),
+		},
+		{
+			Name: "Conditional comments",
+			Node: Body(
+				Div(RevealedIfComment("!mso", P(Text("a")), Span(Text("b")), P(Text("c")))),
+				Div(IfComment("mso", P(Text("a"))), Span(Text("b"))),
+				Ul(RevealedIfComment("!mso", Li(Text("a")), Li(Text("b")))),
+			),
+		},
+		{
+			Name: "Forms",
+			Node: Body(Form(
+				Select(Optgroup(Option(Text("a")), Option(Text("b"))), Option(Value("c d"), Text("c"))),
+				Input(Type("checkbox"), Checked(), Value("")),
+				Textarea(Text("\n  a\n  b")),
+				A(Href("/a/b/"), Text("x")),
+				Img(Src("/x.png"), Alt("")),
+			)),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			full, minified := &strings.Builder{}, &strings.Builder{}
+			page := NewHTML5Doctype(HTML(Head(Title(Text("t"))), tc.Node))
+			if err := RenderTo(context.Background(), full, page); err != nil {
+				t.Fatalf("Error rendering: %s", err)
+			}
+			if err := RenderTo(context.Background(), minified, page, Minify()); err != nil {
+				t.Fatalf("Error rendering: %s", err)
+			}
+			if minified.Len() >= full.Len() {
+				t.Errorf("Expected minified output to be shorter:\n%s", minified)
+			}
+			exp, got := dumpDocument(t, full.String()), dumpDocument(t, minified.String())
+			if exp != got {
+				t.Errorf("Documents differ.\nExpected:\n%s\ngot:\n%s\nfrom:\n%s", exp, got, minified)
+			}
+		})
+	}
+}
+
+// dumpDocument parses src and prints its tree, with whitespace in text
+// collapsed wherever browsers collapse it.
+func dumpDocument(t *testing.T, src string) string {
+	t.Helper()
+	doc, err := html.Parse(strings.NewReader(src))
+	if err != nil {
+		t.Fatalf("Error parsing: %s", err)
+	}
+	sb := &strings.Builder{}
+	var dump func(n *html.Node, depth int, pre bool)
+	dump = func(n *html.Node, depth int, pre bool) {
+		indent := strings.Repeat("  ", depth)
+		switch n.Type {
+		case html.ElementNode:
+			fmt.Fprintf(sb, "%s<%s>", indent, n.Data)
+			for _, a := range n.Attr {
+				fmt.Fprintf(sb, " %s=%q", a.Key, a.Val)
+			}
+			sb.WriteString("\n")
+			pre = pre || preformattedElements[n.Data]
+		case html.TextNode:
+			text := n.Data
+			if !pre {
+				text = collapseSpace(text)
+			}
+			fmt.Fprintf(sb, "%s%q\n", indent, text)
+		case html.DoctypeNode:
+			fmt.Fprintf(sb, "<!DOCTYPE %s>\n", n.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			dump(c, depth+1, pre)
+		}
+	}
+	dump(doc, 0, false)
+	return sb.String()
+}
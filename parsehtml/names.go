@@ -0,0 +1,313 @@
+package parsehtml
+
+// tagFuncs maps tag names to the yahw functions building them.
+var tagFuncs = map[string]string{
+	"a":          "A",
+	"abbr":       "Abbr",
+	"address":    "Address",
+	"area":       "Area",
+	"article":    "Article",
+	"aside":      "Aside",
+	"audio":      "Audio",
+	"b":          "B",
+	"base":       "Base",
+	"bdi":        "Bdi",
+	"bdo":        "Bdo",
+	"blockquote": "Blockquote",
+	"body":       "Body",
+	"br":         "Br",
+	"button":     "Button",
+	"canvas":     "Canvas",
+	"caption":    "Caption",
+	"cite":       "Cite",
+	"code":       "Code",
+	"col":        "Col",
+	"colgroup":   "Colgroup",
+	"data":       "Data",
+	"datalist":   "Datalist",
+	"dd":         "Dd",
+	"del":        "Del",
+	"details":    "Details",
+	"dfn":        "Dfn",
+	"dialog":     "Dialog",
+	"div":        "Div",
+	"dl":         "Dl",
+	"dt":         "Dt",
+	"em":         "Em",
+	"embed":      "Embed",
+	"fieldset":   "Fieldset",
+	"figcaption": "Figcaption",
+	"figure":     "Figure",
+	"footer":     "Footer",
+	"form":       "Form",
+	"h1":         "H1",
+	"h2":         "H2",
+	"h3":         "H3",
+	"h4":         "H4",
+	"h5":         "H5",
+	"h6":         "H6",
+	"head":       "Head",
+	"header":     "Header",
+	"hr":         "Hr",
+	"html":       "HTML",
+	"i":          "I",
+	"iframe":     "Iframe",
+	"img":        "Img",
+	"input":      "Input",
+	"ins":        "Ins",
+	"kbd":        "Kbd",
+	"label":      "Label",
+	"legend":     "Legend",
+	"li":         "Li",
+	"link":       "Link",
+	"main":       "Main",
+	"map":        "Map",
+	"mark":       "Mark",
+	"meta":       "Meta",
+	"meter":      "Meter",
+	"nav":        "Nav",
+	"noscript":   "Noscript",
+	"object":     "Object",
+	"ol":         "Ol",
+	"optgroup":   "Optgroup",
+	"option":     "Option",
+	"output":     "Output",
+	"p":          "P",
+	"param":      "Param",
+	"picture":    "Picture",
+	"pre":        "Pre",
+	"progress":   "Progress",
+	"q":          "Q",
+	"rp":         "Rp",
+	"rt":         "Rt",
+	"ruby":       "Ruby",
+	"s":          "S",
+	"samp":       "Samp",
+	"script":     "Script",
+	"section":    "Section",
+	"select":     "Select",
+	"slot":       "Slot",
+	"small":      "Small",
+	"source":     "Source",
+	"span":       "Span",
+	"strong":     "Strong",
+	"style":      "Style",
+	"sub":        "Sub",
+	"summary":    "Summary",
+	"sup":        "Sup",
+	"table":      "Table",
+	"tbody":      "Tbody",
+	"td":         "Td",
+	"template":   "Template",
+	"textarea":   "Textarea",
+	"tfoot":      "Tfoot",
+	"th":         "Th",
+	"thead":      "Thead",
+	"time":       "Time",
+	"title":      "Title",
+	"tr":         "Tr",
+	"track":      "Track",
+	"u":          "U",
+	"ul":         "Ul",
+	"var":        "Var",
+	"video":      "Video",
+	"wbr":        "Wbr",
+}
+
+// voidTags can't have children, so their yahw functions only take attributes.
+var voidTags = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"link":   true,
+	"meta":   true,
+	"param":  true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
+
+// blockTags are laid out so that whitespace around them isn't rendered.
+// Whitespace between other elements and text is a visible space.
+var blockTags = map[string]bool{
+	"address":    true,
+	"article":    true,
+	"aside":      true,
+	"base":       true,
+	"blockquote": true,
+	"body":       true,
+	"caption":    true,
+	"col":        true,
+	"colgroup":   true,
+	"datalist":   true,
+	"dd":         true,
+	"details":    true,
+	"dialog":     true,
+	"div":        true,
+	"dl":         true,
+	"dt":         true,
+	"fieldset":   true,
+	"figcaption": true,
+	"figure":     true,
+	"footer":     true,
+	"form":       true,
+	"h1":         true,
+	"h2":         true,
+	"h3":         true,
+	"h4":         true,
+	"h5":         true,
+	"h6":         true,
+	"head":       true,
+	"header":     true,
+	"hgroup":     true,
+	"hr":         true,
+	"html":       true,
+	"legend":     true,
+	"li":         true,
+	"link":       true,
+	"main":       true,
+	"menu":       true,
+	"meta":       true,
+	"nav":        true,
+	"ol":         true,
+	"optgroup":   true,
+	"option":     true,
+	"p":          true,
+	"pre":        true,
+	"script":     true,
+	"search":     true,
+	"section":    true,
+	"style":      true,
+	"summary":    true,
+	"table":      true,
+	"tbody":      true,
+	"td":         true,
+	"template":   true,
+	"tfoot":      true,
+	"th":         true,
+	"thead":      true,
+	"title":      true,
+	"tr":         true,
+	"ul":         true,
+}
+
+// attrFuncs maps attribute names to yahw functions taking the value.
+var attrFuncs = map[string]string{
+	"accept":          "Accept",
+	"accesskey":       "AccessKey",
+	"action":          "Action",
+	"alt":             "Alt",
+	"autocomplete":    "AutoComplete",
+	"autofocus":       "AutoFocus",
+	"autosave":        "AutoSave",
+	"axis":            "Axis",
+	"charset":         "Charset",
+	"class":           "Classes",
+	"colspan":         "ColSpan",
+	"content":         "Content",
+	"contenteditable": "ContentEditable",
+	"contextmenu":     "ContextMenu",
+	"coords":          "Coords",
+	"crossorigin":     "CrossOrigin",
+	"datetime":        "DateTime",
+	"default":         "Default",
+	"dir":             "Dir",
+	"download":        "Download",
+	"draggable":       "Draggable",
+	"dropzone":        "DropZone",
+	"enctype":         "EncType",
+	"for":             "For",
+	"form":            "FormAttr",
+	"formaction":      "FormAction",
+	"formenctype":     "FormEncType",
+	"formmethod":      "FormMethod",
+	"formnovalidate":  "FormNoValidate",
+	"formtarget":      "FormTarget",
+	"headers":         "Headers",
+	"height":          "Height",
+	"hidden":          "Hidden",
+	"high":            "High",
+	"href":            "Href",
+	"hreflang":        "HrefLang",
+	"http-equiv":      "HttpEquiv",
+	"id":              "ID",
+	"integrity":       "Integrity",
+	"ismap":           "IsMap",
+	"keytype":         "KeyType",
+	"kind":            "Kind",
+	"label":           "LabelAttr",
+	"lang":            "Lang",
+	"list":            "List",
+	"longdesc":        "LongDesc",
+	"low":             "Low",
+	"max":             "Max",
+	"maxlength":       "MaxLength",
+	"media":           "Media",
+	"method":          "Method",
+	"min":             "Min",
+	"minlength":       "MinLength",
+	"multiple":        "Multiple",
+	"name":            "Name",
+	"onabort":         "OnAbort",
+	"onblur":          "OnBlur",
+	"onchange":        "OnChange",
+	"onclick":         "OnClick",
+	"onerror":         "OnError",
+	"onfocus":         "OnFocus",
+	"onkeydown":       "OnKeyDown",
+	"onkeypress":      "OnKeyPress",
+	"onkeyup":         "OnKeyUp",
+	"onload":          "OnLoad",
+	"onmousedown":     "OnMouseDown",
+	"onmouseout":      "OnMouseOut",
+	"onmouseover":     "OnMouseOver",
+	"onmouseup":       "OnMouseUp",
+	"onreset":         "OnReset",
+	"onresize":        "OnResize",
+	"onscroll":        "OnScroll",
+	"onselect":        "OnSelect",
+	"onsubmit":        "OnSubmit",
+	"onunload":        "OnUnload",
+	"optimum":         "Optimum",
+	"pattern":         "Pattern",
+	"ping":            "Ping",
+	"placeholder":     "Placeholder",
+	"referrerpolicy":  "ReferrerPolicy",
+	"rel":             "Rel",
+	"rev":             "Rev",
+	"role":            "Role",
+	"rowspan":         "RowSpan",
+	"scheme":          "Scheme",
+	"scope":           "Scope",
+	"shape":           "Shape",
+	"size":            "Size",
+	"sizes":           "Sizes",
+	"spellcheck":      "SpellCheck",
+	"src":             "Src",
+	"srclang":         "SrcLang",
+	"srcset":          "SrcSet",
+	"step":            "Step",
+	"style":           "StyleAttr",
+	"tabindex":        "TabIndex",
+	"target":          "Target",
+	"title":           "TitleAttr",
+	"translate":       "Translate",
+	"type":            "Type",
+	"usemap":          "UseMap",
+	"value":           "Value",
+	"width":           "Width",
+}
+
+// boolAttrFuncs maps boolean attribute names to yahw functions without arguments.
+var boolAttrFuncs = map[string]string{
+	"checked":    "Checked",
+	"disabled":   "Disabled",
+	"novalidate": "NoValidate",
+	"readonly":   "ReadOnly",
+	"required":   "Required",
+}
@@ -1,46 +1,209 @@
 package parsehtml
 
 import (
+	"bytes"
 	"fmt"
 	"go/format"
+	"go/token"
 	"io"
+	"strconv"
 	"strings"
 
 	"golang.org/x/net/html"
 	"golang.org/x/net/html/atom"
 )
 
+// GenerateGo converts an HTML fragment into a return statement building it
+// with yahw. It panics on invalid input, see Generate.
 func GenerateGo(in io.Reader) string {
-	nodes, err := html.ParseFragment(in, &html.Node{
+	code, err := Generate(in, false)
+	if err != nil {
+		panic(err)
+	}
+	return code
+}
+
+// GenerateDocumentGo is like GenerateGo, but parses in as a whole document.
+// The result is wrapped in yahw.NewHTML5Doctype if the document has a doctype.
+func GenerateDocumentGo(in io.Reader) string {
+	code, err := Generate(in, true)
+	if err != nil {
+		panic(err)
+	}
+	return code
+}
+
+// Generate converts HTML into a return statement building it with yahw. If
+// document is set, in is parsed as a whole document, otherwise as the contents
+// of a body element. Problems with the input are reported as *SyntaxError.
+func Generate(in io.Reader, document bool) (string, error) {
+	src, err := io.ReadAll(in)
+	if err != nil {
+		return "", err
+	}
+	if err := validate(src); err != nil {
+		return "", err
+	}
+
+	buf := strings.Builder{}
+	if document {
+		err = writeDocument(&buf, src)
+	} else {
+		err = writeFragment(&buf, src)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	bz, err := format.Source([]byte(buf.String()))
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
+
+// FileConfig configures GenerateFile.
+type FileConfig struct {
+	// Package is the name of the generated package.
+	Package string
+	// Func is the name of the function returning the converted HTML.
+	Func string
+	// Document parses the input as a whole document, see Generate.
+	Document bool
+}
+
+// GenerateFile converts HTML into a Go source file with a single function
+// returning it as a yahw.Node.
+func GenerateFile(in io.Reader, cfg FileConfig) (string, error) {
+	if !token.IsIdentifier(cfg.Package) {
+		return "", fmt.Errorf("invalid package name %q", cfg.Package)
+	}
+	if !token.IsIdentifier(cfg.Func) {
+		return "", fmt.Errorf("invalid function name %q", cfg.Func)
+	}
+
+	code, err := Generate(in, cfg.Document)
+	if err != nil {
+		return "", err
+	}
+
+	src := fmt.Sprintf("package %s\n\nimport \"github.com/vizualni/yahw\"\n\nfunc %s() yahw.Node {\n%s\n}\n", cfg.Package, cfg.Func, code)
+	bz, err := format.Source([]byte(src))
+	if err != nil {
+		return "", err
+	}
+	return string(bz), nil
+}
+
+func writeFragment(w io.Writer, src []byte) error {
+	nodes, err := html.ParseFragment(bytes.NewReader(src), &html.Node{
 		Type:     html.ElementNode,
 		Data:     "body",
 		DataAtom: atom.Body,
 	})
 	if err != nil {
-		panic(err)
+		return err
 	}
 
-	buf := strings.Builder{}
-	buf.WriteString("return yahw.TagSlice{\n")
+	w.Write([]byte("return yahw.TagSlice{\n"))
 	for _, node := range nodes {
-		if node.Type != html.ElementNode {
-			continue
-		}
-		writeNode(&buf, node)
-		buf.WriteString(",\n")
+		writeChild(w, node, false)
 	}
-	buf.WriteString("}")
-	code := buf.String()
-	fmt.Println(code)
-	bz, err := format.Source([]byte(code))
+	w.Write([]byte("}"))
+	return nil
+}
+
+func writeDocument(w io.Writer, src []byte) error {
+	doc, err := html.Parse(bytes.NewReader(src))
 	if err != nil {
-		panic(err)
+		return err
+	}
+
+	hasDoctype := false
+	for next := doc.FirstChild; next != nil; next = next.NextSibling {
+		if next.Type == html.DoctypeNode {
+			hasDoctype = true
+		}
+	}
+
+	if hasDoctype {
+		w.Write([]byte("return yahw.NewHTML5Doctype(\n"))
+	} else {
+		w.Write([]byte("return yahw.TagSlice{\n"))
+	}
+	for next := doc.FirstChild; next != nil; next = next.NextSibling {
+		writeChild(w, next, false)
 	}
-	formatted := string(bz)
-	return formatted
+	if hasDoctype {
+		w.Write([]byte(")"))
+	} else {
+		w.Write([]byte("}"))
+	}
+	return nil
 }
 
-func writeNode(w io.Writer, node *html.Node) {
+// preservesSpace reports whether whitespace within tag is rendered as is.
+func preservesSpace(tag string) bool {
+	switch tag {
+	case "pre", "textarea", "listing", "plaintext":
+		return true
+	}
+	return false
+}
+
+// writeChild writes node followed by a comma. Whitespace only text is
+// dropped unless preserveSpace is set or browsers render it as a space.
+func writeChild(w io.Writer, node *html.Node, preserveSpace bool) {
+	switch node.Type {
+	case html.ElementNode:
+		writeNode(w, node, preserveSpace)
+		w.Write([]byte(",\n"))
+	case html.TextNode:
+		if preserveSpace || len(strings.TrimSpace(node.Data)) > 0 || isInlineSpace(node) {
+			fmt.Fprintf(w, "yahw.Text(%s),\n", strconv.Quote(node.Data))
+		}
+	case html.CommentNode:
+		fmt.Fprintf(w, "yahw.Comment(%s),\n", strconv.Quote(node.Data))
+	}
+}
+
+// isInlineSpace reports whether the whitespace only text node is between
+// inline content on both sides, like the space in "<b>a</b> <i>b</i>".
+func isInlineSpace(node *html.Node) bool {
+	prev := func(n *html.Node) *html.Node { return n.PrevSibling }
+	next := func(n *html.Node) *html.Node { return n.NextSibling }
+	return inlineSibling(node, prev) && inlineSibling(node, next)
+}
+
+// inlineSibling reports whether the content next to node in the direction of
+// sibling is text or an inline element. Comments are skipped, and at the edge
+// of an inline element, the search continues past it.
+func inlineSibling(node *html.Node, sibling func(*html.Node) *html.Node) bool {
+	for {
+		n := sibling(node)
+		for n != nil && n.Type == html.CommentNode {
+			n = sibling(n)
+		}
+		switch {
+		case n == nil:
+			parent := node.Parent
+			if parent == nil || parent.Type != html.ElementNode || blockTags[parent.Data] {
+				return false
+			}
+			node = parent
+		case n.Type == html.TextNode:
+			return true
+		case n.Type == html.ElementNode:
+			return !blockTags[n.Data]
+		default:
+			return false
+		}
+	}
+}
+
+// writeNode writes node using its named yahw function, e.g. yahw.Div(...).
+// Tags without one are built with yahw.TagBuilder.
+func writeNode(w io.Writer, node *html.Node, preserveSpace bool) {
 	if node == nil {
 		return
 	}
@@ -48,26 +211,21 @@ func writeNode(w io.Writer, node *html.Node) {
 		panic("can only create a tag from the element node")
 	}
 	tag := node.Data
-	code := fmt.Sprintf(`yahw.NewTag("%s")`, tag)
-	w.Write([]byte(code))
-	if len(node.Attr) > 0 {
-		w.Write([]byte(".Attrs(\n"))
-		writeAttrs(w, node)
-		w.Write([]byte("\n)"))
-	}
-	w.Write([]byte(".X(\n"))
+	if fn, ok := tagFuncs[tag]; ok {
+		fmt.Fprintf(w, "yahw.%s(\n", fn)
+	} else {
+		fmt.Fprintf(w, "yahw.TagBuilder(%s)(\n", strconv.Quote(tag))
+	}
+	writeAttrs(w, node)
+	if voidTags[tag] {
+		w.Write([]byte(")"))
+		return
+	}
+	preserveSpace = preserveSpace || preservesSpace(tag)
 	for next := node.FirstChild; next != nil; next = next.NextSibling {
-		switch next.Type {
-		case html.ElementNode:
-			writeNode(w, next)
-			w.Write([]byte(",\n"))
-		case html.TextNode:
-			if len(strings.TrimSpace(next.Data)) > 0 {
-				fmt.Fprintf(w, "yahw.Text(`%s`),", next.Data)
-			}
-		}
+		writeChild(w, next, preserveSpace)
 	}
-	w.Write([]byte("\n)"))
+	w.Write([]byte(")"))
 }
 
 func writeAttrs(w io.Writer, node *html.Node) {
@@ -75,13 +233,27 @@ func writeAttrs(w io.Writer, node *html.Node) {
 		panic("can only get tags from the element node")
 	}
 
-	if len(node.Attr) == 0 {
-		return
-	}
-
 	for _, attr := range node.Attr {
-		code := fmt.Sprintf("yahw.Attr(\"%s\", `%s`),", attr.Key, attr.Val)
+		key := attr.Key
+		if attr.Namespace != "" {
+			key = attr.Namespace + ":" + key
+		}
+		val := strconv.Quote(attr.Val)
+
+		var code string
+		switch {
+		case boolAttrFuncs[key] != "":
+			code = fmt.Sprintf("yahw.%s()", boolAttrFuncs[key])
+		case attrFuncs[key] != "":
+			code = fmt.Sprintf("yahw.%s(%s)", attrFuncs[key], val)
+		case strings.HasPrefix(key, "data-"):
+			code = fmt.Sprintf("yahw.DataAttr(%s, %s)", strconv.Quote(strings.TrimPrefix(key, "data-")), val)
+		case strings.HasPrefix(key, "aria-"):
+			code = fmt.Sprintf("yahw.Aria(%s, %s)", strconv.Quote(strings.TrimPrefix(key, "aria-")), val)
+		default:
+			code = fmt.Sprintf("yahw.BuildAttr(%s, %s)", strconv.Quote(key), val)
+		}
 		w.Write([]byte(code))
-		w.Write([]byte("\n"))
+		w.Write([]byte(",\n"))
 	}
 }
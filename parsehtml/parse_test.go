@@ -0,0 +1,405 @@
+package parsehtml
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// The source importer caches type-checked packages, so it's shared between tests.
+var (
+	fset = token.NewFileSet()
+	imp  = importer.ForCompiler(fset, "source", nil)
+)
+
+// typeCheck compiles code as the body of a function returning a yahw.Node.
+func typeCheck(t *testing.T, code string) {
+	t.Helper()
+
+	src := "package generated\n\nimport \"github.com/vizualni/yahw\"\n\nfunc generated() yahw.Node {\n" + code + "\n}\n"
+
+	// The file must be within the module so the yahw import can be resolved.
+	dir, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := parser.ParseFile(fset, filepath.Join(dir, "generated.go"), src, 0)
+	if err != nil {
+		t.Fatalf("Generated code doesn't parse: %s\n%s", err, src)
+	}
+
+	conf := types.Config{Importer: imp}
+	if _, err := conf.Check("generated", fset, []*ast.File{f}, nil); err != nil {
+		t.Fatalf("Generated code doesn't compile: %s\n%s", err, src)
+	}
+}
+
+func TestGenerateGo(t *testing.T) {
+	in := `<div class="a b" id="x"><a href="/foo" data-id="1" aria-label="l">Hello <b>"world"</b></a><input type="text" disabled><my-el foo="bar"></my-el></div><p>x</p>`
+	expected := `return yahw.TagSlice{
+	yahw.Div(
+		yahw.Classes("a b"),
+		yahw.ID("x"),
+		yahw.A(
+			yahw.Href("/foo"),
+			yahw.DataAttr("id", "1"),
+			yahw.Aria("label", "l"),
+			yahw.Text("Hello "),
+			yahw.B(
+				yahw.Text("\"world\""),
+			),
+		),
+		yahw.Input(
+			yahw.Type("text"),
+			yahw.Disabled(),
+		),
+		yahw.TagBuilder("my-el")(
+			yahw.BuildAttr("foo", "bar"),
+		),
+	),
+	yahw.P(
+		yahw.Text("x"),
+	),
+}`
+
+	code := GenerateGo(strings.NewReader(in))
+	if code != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, code)
+	}
+	typeCheck(t, code)
+}
+
+func TestGeneratedCodeCompiles(t *testing.T) {
+	attrs := []string{}
+	for name := range attrFuncs {
+		attrs = append(attrs, name+`="x"`)
+	}
+	for name := range boolAttrFuncs {
+		attrs = append(attrs, name)
+	}
+	attrs = append(attrs, `data-foo="x"`, `aria-bar="x"`, `unknown="x"`)
+	sort.Strings(attrs)
+
+	tags := []string{}
+	for name := range tagFuncs {
+		tags = append(tags, name)
+	}
+	sort.Strings(tags)
+
+	var sb strings.Builder
+	sb.WriteString("<div " + strings.Join(attrs, " ") + ">")
+	sb.WriteString(`<svg><a xlink:href="#x"></a></svg>`)
+	for _, tag := range tags {
+		if voidTags[tag] {
+			sb.WriteString("<" + tag + " " + strings.Join(attrs, " ") + ">")
+		} else {
+			sb.WriteString("<" + tag + ">text</" + tag + ">")
+		}
+	}
+	sb.WriteString("</div>")
+
+	typeCheck(t, GenerateGo(strings.NewReader(sb.String())))
+}
+
+func TestGenerateGoKeepsCommentsAndText(t *testing.T) {
+	in := "before <!-- a comment --><p>x</p>after"
+	expected := `return yahw.TagSlice{
+	yahw.Text("before "),
+	yahw.Comment(" a comment "),
+	yahw.P(
+		yahw.Text("x"),
+	),
+	yahw.Text("after"),
+}`
+
+	code := GenerateGo(strings.NewReader(in))
+	if code != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, code)
+	}
+	typeCheck(t, code)
+}
+
+func TestGenerateGoPreservesWhitespace(t *testing.T) {
+	in := "<div>\n  <pre>  a\n <b> </b>\n</pre>\n  <textarea>\n\n x </textarea>\n</div>"
+	expected := `return yahw.TagSlice{
+	yahw.Div(
+		yahw.Pre(
+			yahw.Text("  a\n "),
+			yahw.B(
+				yahw.Text(" "),
+			),
+			yahw.Text("\n"),
+		),
+		yahw.Textarea(
+			yahw.Text("\n x "),
+		),
+	),
+}`
+
+	code := GenerateGo(strings.NewReader(in))
+	if code != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, code)
+	}
+	typeCheck(t, code)
+}
+
+func TestGenerateGoKeepsSpaceBetweenInlineElements(t *testing.T) {
+	in := "<div>\n  <p><b>a</b> <i>b</i>\n</p>\n</div>"
+	expected := `return yahw.TagSlice{
+	yahw.Div(
+		yahw.P(
+			yahw.B(
+				yahw.Text("a"),
+			),
+			yahw.Text(" "),
+			yahw.I(
+				yahw.Text("b"),
+			),
+		),
+	),
+}`
+
+	code := GenerateGo(strings.NewReader(in))
+	if code != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, code)
+	}
+	typeCheck(t, code)
+}
+
+func TestGenerateDocumentGo(t *testing.T) {
+	in := `<!DOCTYPE html>
+<!-- top -->
+<html lang="en">
+<head><title>Hi</title><meta charset="utf-8"></head>
+<body><p>x</p><!-- bottom --></body>
+</html>`
+	expected := `return yahw.NewHTML5Doctype(
+	yahw.Comment(" top "),
+	yahw.HTML(
+		yahw.Lang("en"),
+		yahw.Head(
+			yahw.Title(
+				yahw.Text("Hi"),
+			),
+			yahw.Meta(
+				yahw.Charset("utf-8"),
+			),
+		),
+		yahw.Body(
+			yahw.P(
+				yahw.Text("x"),
+			),
+			yahw.Comment(" bottom "),
+		),
+	),
+)`
+
+	code := GenerateDocumentGo(strings.NewReader(in))
+	if code != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, code)
+	}
+	typeCheck(t, code)
+}
+
+func TestGenerateDocumentGoWithoutDoctype(t *testing.T) {
+	expected := `return yahw.TagSlice{
+	yahw.HTML(
+		yahw.Head(),
+		yahw.Body(
+			yahw.Text("x"),
+		),
+	),
+}`
+
+	code := GenerateDocumentGo(strings.NewReader("x"))
+	if code != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, code)
+	}
+	typeCheck(t, code)
+}
+
+func TestGenerateFile(t *testing.T) {
+	code, err := GenerateFile(strings.NewReader("<p>x</p>"), FileConfig{Package: "views", Func: "Hello"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := `package views
+
+import "github.com/vizualni/yahw"
+
+func Hello() yahw.Node {
+	return yahw.TagSlice{
+		yahw.P(
+			yahw.Text("x"),
+		),
+	}
+}
+`
+	if code != expected {
+		t.Errorf("Expected\n%s\ngot\n%s", expected, code)
+	}
+
+	if _, err := GenerateFile(strings.NewReader("<p>x</p>"), FileConfig{Package: "views", Func: "1x"}); err == nil {
+		t.Errorf("Expected an error for an invalid function name")
+	}
+	if _, err := GenerateFile(strings.NewReader("<p>x</p>"), FileConfig{Package: "my-views", Func: "X"}); err == nil {
+		t.Errorf("Expected an error for an invalid package name")
+	}
+}
+
+func TestGenerateSyntaxErrors(t *testing.T) {
+	tt := []struct {
+		Name string
+		In   string
+		Exp  string
+	}{
+		{Name: "Attribute name", In: "<div>\n  <p a\"b=\"c\">x</p></div>", Exp: `2:3: invalid attribute name: "a\"b"`},
+		{Name: "Tag name", In: "<div><p>x</p><foo:bar></foo:bar></div>", Exp: `1:14: invalid tag name: "foo:bar"`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := Generate(strings.NewReader(tc.In), false)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Expected *SyntaxError, got %v", err)
+			}
+			if err.Error() != tc.Exp {
+				t.Errorf("Expected %s, got %s", tc.Exp, err)
+			}
+		})
+	}
+}
+
+// renderGenerated runs the generated code and returns the HTML it renders.
+func renderGenerated(t *testing.T, code string) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("runs the go command")
+	}
+	goCmd, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not found")
+	}
+
+	// The program must be within the module so the yahw import can be resolved.
+	dir, err := os.MkdirTemp(".", "roundtrip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	src := "package main\n\nimport (\n\t\"context\"\n\t\"os\"\n\n\t\"github.com/vizualni/yahw\"\n)\n\nfunc generated() yahw.Node {\n" + code + "\n}\n\n" +
+		"func main() {\n\tif err := yahw.RenderTo(context.Background(), os.Stdout, generated()); err != nil {\n\t\tpanic(err)\n\t}\n}\n"
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := exec.Command(goCmd, "run", "./"+dir).Output()
+	if err != nil {
+		t.Fatalf("Error running generated code: %s\n%s", err, src)
+	}
+	return string(out)
+}
+
+// visibleContent describes how browsers show the fragment src: elements with
+// their attributes and the text of each block, with whitespace collapsed
+// except in whitespace-sensitive elements.
+func visibleContent(t *testing.T, src string) string {
+	t.Helper()
+	nodes, err := html.ParseFragment(strings.NewReader(src), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		t.Fatalf("Error parsing: %s", err)
+	}
+
+	sb, line := &strings.Builder{}, &strings.Builder{}
+	flush := func() {
+		s := strings.Join(strings.Fields(line.String()), " ")
+		if s != "" {
+			fmt.Fprintf(sb, "%q\n", s)
+		}
+		line.Reset()
+	}
+	var walk func(n *html.Node, pre bool)
+	walk = func(n *html.Node, pre bool) {
+		switch n.Type {
+		case html.TextNode:
+			if pre {
+				flush()
+				fmt.Fprintf(sb, "pre %q\n", n.Data)
+				return
+			}
+			line.WriteString(n.Data)
+		case html.ElementNode:
+			block := blockTags[n.Data] || preservesSpace(n.Data)
+			tag := "<" + n.Data
+			for _, a := range n.Attr {
+				tag += fmt.Sprintf(" %s=%q", a.Key, a.Val)
+			}
+			tag += ">"
+			if block {
+				flush()
+				sb.WriteString(tag + "\n")
+			} else {
+				line.WriteString(tag)
+			}
+			for c := n.FirstChild; c != nil; c = c.NextSibling {
+				walk(c, pre || preservesSpace(n.Data))
+			}
+			if block {
+				flush()
+				sb.WriteString("</" + n.Data + ">\n")
+			} else {
+				line.WriteString("</" + n.Data + ">")
+			}
+		}
+	}
+	for _, n := range nodes {
+		walk(n, false)
+	}
+	flush()
+	return sb.String()
+}
+
+func TestGenerateGoRoundTrip(t *testing.T) {
+	ins := []string{
+		"<pre>\n\nfoo</pre>",
+		"<div>\n  <pre>  a\n <b> </b>\n</pre>\n  <textarea>\n\n x </textarea>\n</div>",
+		"<listing>\n\nx</listing>",
+		"<p><b>a</b> <i>b</i></p>",
+		"<p>x <span><b>a</b> </span><i>b</i>\n<!-- c -->\n<a href=\"#\">c</a></p>",
+		"<ul>\n  <li>a</li>\n  <li><em>b</em> <strong>c</strong></li>\n</ul>",
+	}
+
+	var sb strings.Builder
+	sb.WriteString("return yahw.TagSlice{\n")
+	for _, in := range ins {
+		sb.WriteString("yahw.Raw(\"\\x00\"),\n")
+		code := GenerateGo(strings.NewReader(in))
+		sb.WriteString(strings.TrimSuffix(strings.TrimPrefix(code, "return yahw.TagSlice{\n"), "}") + "\n")
+	}
+	sb.WriteString("}")
+
+	// All inputs are rendered in a single run, separated by NUL bytes.
+	outs := strings.Split(renderGenerated(t, sb.String()), "\x00")[1:]
+	if len(outs) != len(ins) {
+		t.Fatalf("Expected %d outputs, got %d", len(ins), len(outs))
+	}
+	for i, in := range ins {
+		if exp, got := visibleContent(t, in), visibleContent(t, outs[i]); exp != got {
+			t.Errorf("%q renders differently as %q.\nExpected:\n%s\ngot:\n%s", in, outs[i], exp, got)
+		}
+	}
+}
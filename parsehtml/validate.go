@@ -0,0 +1,62 @@
+package parsehtml
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/vizualni/yahw"
+	"golang.org/x/net/html"
+)
+
+// SyntaxError is a problem at a position of the input HTML.
+type SyntaxError struct {
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Err)
+}
+
+func (e *SyntaxError) Unwrap() error { return e.Err }
+
+func newSyntaxError(src []byte, offset int, err error) *SyntaxError {
+	line := bytes.Count(src[:offset], []byte("\n")) + 1
+	col := offset - bytes.LastIndexByte(src[:offset], '\n')
+	return &SyntaxError{Line: line, Column: col, Err: err}
+}
+
+// validate checks that the generated code won't panic because of tag or
+// attribute names yahw doesn't accept. The parser doesn't keep track of
+// positions, so the source is tokenized separately.
+func validate(src []byte) error {
+	z := html.NewTokenizer(bytes.NewReader(src))
+	offset := 0
+	for {
+		tt := z.Next()
+		start := offset
+		offset += len(z.Raw())
+
+		switch tt {
+		case html.ErrorToken:
+			if z.Err() == io.EOF {
+				return nil
+			}
+			return newSyntaxError(src, start, z.Err())
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			if _, err := yahw.TryTagBuilder(string(name)); err != nil {
+				return newSyntaxError(src, start, err)
+			}
+			for hasAttr {
+				var key []byte
+				key, _, hasAttr = z.TagAttr()
+				if _, err := yahw.TryBuildAttr(string(key), ""); err != nil {
+					return newSyntaxError(src, start, err)
+				}
+			}
+		}
+	}
+}
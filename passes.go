@@ -0,0 +1,183 @@
+package yahw
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DedupeIDs returns a copy of t in which every id is unique. The first
+// element with an id keeps it, later ones get a number appended, e.g.
+// "name-2". References to the id keep pointing to the first element.
+func DedupeIDs(t *Tree) *Tree {
+	taken := map[string]bool{}
+	walkTree(t.root, func(r Renderable) {
+		if id, ok := elementID(r); ok {
+			taken[id] = true
+		}
+	})
+
+	seen := map[string]bool{}
+	root := mapTree(t.root, func(r Renderable) Renderable {
+		id, ok := elementID(r)
+		if !ok {
+			return r
+		}
+		if !seen[id] {
+			seen[id] = true
+			return r
+		}
+		n := 2
+		for taken[id+"-"+strconv.Itoa(n)] {
+			n++
+		}
+		unique := id + "-" + strconv.Itoa(n)
+		taken[unique] = true
+		seen[unique] = true
+		return replaceAttr(r, "id", BuildAttr("id", unique))
+	})
+	return &Tree{ctx: t.ctx, root: root}
+}
+
+// BrokenLinkError is returned by ValidateLinks for a reference to an id that
+// isn't in the tree.
+type BrokenLinkError struct {
+	// Tag and Attr are the element and the attribute with the reference.
+	Tag, Attr string
+	ID        string
+}
+
+func (e *BrokenLinkError) Error() string {
+	return fmt.Sprintf("%s %s refers to missing id %q", e.Tag, e.Attr, e.ID)
+}
+
+// idRefAttrs are the attributes referring to elements by id. Those listing
+// several ids are set to true.
+var idRefAttrs = map[string]bool{
+	"for":                   true,
+	"form":                  false,
+	"list":                  false,
+	"headers":               true,
+	"popovertarget":         false,
+	"aria-activedescendant": false,
+	"aria-controls":         true,
+	"aria-describedby":      true,
+	"aria-details":          true,
+	"aria-errormessage":     true,
+	"aria-flowto":           true,
+	"aria-labelledby":       true,
+	"aria-owns":             true,
+}
+
+// ValidateLinks checks that every fragment link like href="#name" and every
+// attribute referring to an id, like for and aria-labelledby, points to an
+// element in t. Each broken reference is reported as a *BrokenLinkError.
+func ValidateLinks(t *Tree) error {
+	ids := map[string]bool{}
+	walkTree(t.root, func(r Renderable) {
+		if id, ok := elementID(r); ok {
+			ids[id] = true
+		}
+	})
+
+	var errs []error
+	walkTree(t.root, func(r Renderable) {
+		name, attrs := elementOf(r)
+		for _, attr := range attrs {
+			named, ok := attr.(namedAttr)
+			if !ok {
+				continue
+			}
+			key := strings.ToLower(named.attrKey())
+			for _, ref := range idRefs(key, named.attrValue()) {
+				if !ids[ref] {
+					errs = append(errs, &BrokenLinkError{Tag: name, Attr: key, ID: ref})
+				}
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// idRefs returns the ids the attribute key with value refers to.
+func idRefs(key, value string) []string {
+	if key == "href" {
+		fragment, ok := strings.CutPrefix(value, "#")
+		if !ok || fragment == "" || strings.EqualFold(fragment, "top") {
+			return nil
+		}
+		if unescaped, err := url.PathUnescape(fragment); err == nil {
+			fragment = unescaped
+		}
+		return []string{fragment}
+	}
+
+	several, ok := idRefAttrs[key]
+	if !ok {
+		return nil
+	}
+	if several {
+		return strings.Fields(value)
+	}
+	if value = strings.TrimSpace(value); value != "" {
+		return []string{value}
+	}
+	return nil
+}
+
+// elementID returns the id r is rendered with, if it's an element with one.
+func elementID(r Renderable) (string, bool) {
+	_, attrs := elementOf(r)
+	id, found := "", false
+	for _, attr := range attrs {
+		if named, ok := attr.(namedAttr); ok && strings.EqualFold(named.attrKey(), "id") {
+			id, found = named.attrValue(), true
+		}
+	}
+	return id, found && id != ""
+}
+
+// replaceAttr returns the element r with the attributes named key replaced
+// by attr, at the position of the first one.
+func replaceAttr(r Renderable, key string, attr attrable) Renderable {
+	replace := func(a attrable) (attrable, bool) {
+		named, ok := a.(namedAttr)
+		return attr, ok && strings.EqualFold(named.attrKey(), key)
+	}
+
+	switch t := r.(type) {
+	case SelfClosingTag:
+		attrs := []attrable{}
+		replaced := false
+		for _, a := range t.attrs {
+			if repl, ok := replace(a); ok {
+				if !replaced {
+					attrs = append(attrs, repl)
+					replaced = true
+				}
+				continue
+			}
+			attrs = append(attrs, a)
+		}
+		return SelfClosingTag{tagName: t.tagName, attrs: attrs}
+	case CommonTag:
+		children := []Node{}
+		replaced := false
+		for _, child := range t.children {
+			if a, ok := child.(attrable); ok {
+				if repl, ok := replace(a); ok {
+					if !replaced {
+						children = append(children, asNode(repl))
+						replaced = true
+					}
+					continue
+				}
+			}
+			children = append(children, child)
+		}
+		return CommonTag{tagName: t.tagName, children: children}
+	}
+	return r
+}
@@ -0,0 +1,90 @@
+package yahw
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func resolveTest(t *testing.T, node Node) *Tree {
+	t.Helper()
+	tree, err := Resolve(context.Background(), node)
+	if err != nil {
+		t.Fatalf("Error resolving: %s", err)
+	}
+	return tree
+}
+
+func serializeString(t *testing.T, tree *Tree) string {
+	t.Helper()
+	strbuf := &strings.Builder{}
+	if err := Serialize(tree, strbuf); err != nil {
+		t.Fatalf("Error serializing: %s", err)
+	}
+	return strbuf.String()
+}
+
+type field struct {
+	name string
+}
+
+func (f field) Node(ctx context.Context) Renderable {
+	return Fragment{Label(For(f.name), Text(f.name)), Input(ID(f.name), Name(f.name))}
+}
+
+func TestDedupeIDs(t *testing.T) {
+	tree := resolveTest(t, Form(field{name: "q"}, field{name: "q"}, Div(ID("q-2")), field{name: "q"}, Input(ID("x"), ID("q"))))
+	deduped := DedupeIDs(tree)
+
+	exp := `<form><label for="q">q</label><input id="q" name="q" />` +
+		`<label for="q">q</label><input id="q-3" name="q" />` +
+		`<div id="q-2"></div>` +
+		`<label for="q">q</label><input id="q-4" name="q" />` +
+		`<input id="q-5" /></form>`
+	if got := serializeString(t, deduped); got != exp {
+		t.Errorf("Expected:\n%s\ngot:\n%s", exp, got)
+	}
+	if got := serializeString(t, tree); strings.Count(got, `<input id="q"`) != 4 {
+		t.Errorf("Expected the original tree to be unchanged, got %s", got)
+	}
+}
+
+func TestValidateLinks(t *testing.T) {
+	tree := resolveTest(t, Body(
+		A(Href("#main"), Text("skip")),
+		A(Href("#top")),
+		A(Href("/page#missing")),
+		A(Href("#caf%C3%A9")),
+		Main(ID("main"), H2(ID("café"))),
+		Label(For("email")),
+		Input(ID("name"), Aria("describedby", "hint name")),
+		Div(Aria("labelledby", "title")),
+	))
+
+	err := ValidateLinks(tree)
+	var broken []*BrokenLinkError
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var ble *BrokenLinkError
+		if errors.As(e, &ble) {
+			broken = append(broken, ble)
+		}
+	}
+	exp := []BrokenLinkError{
+		{Tag: "label", Attr: "for", ID: "email"},
+		{Tag: "input", Attr: "aria-describedby", ID: "hint"},
+		{Tag: "div", Attr: "aria-labelledby", ID: "title"},
+	}
+	if len(broken) != len(exp) {
+		t.Fatalf("Expected %d broken links, got %v", len(exp), err)
+	}
+	for i := range exp {
+		if *broken[i] != exp[i] {
+			t.Errorf("Expected %v, got %v", exp[i], *broken[i])
+		}
+	}
+
+	if err := ValidateLinks(resolveTest(t, Div(A(Href("#a")), Span(ID("a"))))); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,171 @@
+package yahw
+
+import (
+	"context"
+	"io"
+	"strings"
+)
+
+// Indent makes RenderTo put every block element on its own line, indented
+// with indent once per level of nesting. Whitespace is only added where
+// browsers ignore it: between block elements, never around text or inline
+// elements and never within whitespace-sensitive elements like pre.
+func Indent(indent string) RenderOption {
+	return func(c *renderConfig) {
+		c.indent = indent
+	}
+}
+
+type layoutKey struct{}
+
+// layout is where the element being rendered sits in indented output.
+type layout struct {
+	indent string
+	depth  int
+	// preformatted is set within elements whose whitespace must be kept as
+	// is, which are whitespace-sensitive and all but block elements.
+	preformatted bool
+}
+
+func withLayout(ctx context.Context, l layout) context.Context {
+	return context.WithValue(ctx, layoutKey{}, l)
+}
+
+// layoutOf returns the current layout. It reports false if output isn't
+// indented.
+func layoutOf(ctx context.Context) (layout, bool) {
+	l, ok := ctx.Value(layoutKey{}).(layout)
+	return l, ok
+}
+
+// child returns the layout of the children of tagName.
+func (l layout) child(tagName string) layout {
+	tagName = strings.ToLower(tagName)
+	return layout{
+		indent:       l.indent,
+		depth:        l.depth + 1,
+		preformatted: l.preformatted || preformattedElements[tagName] || !blockElements[tagName],
+	}
+}
+
+// newline starts a new line indented to depth.
+func (l layout) newline(w io.Writer, depth int) error {
+	if err := writeStrings(w, "\n"); err != nil {
+		return err
+	}
+	for i := 0; i < depth; i++ {
+		if err := writeStrings(w, l.indent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// breaksBetween reports whether children can be put on lines of their own
+// without changing how they're rendered.
+func breaksBetween[T Renderable](l layout, children []T) bool {
+	if l.preformatted {
+		return false
+	}
+	found := false
+	for _, child := range children {
+		if Renderable(child) == nil {
+			continue
+		}
+		if !isBlock(child) {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// isBlock reports whether whitespace around r is ignored by browsers.
+func isBlock(r Renderable) bool {
+	switch r := r.(type) {
+	case CommonTag:
+		return blockElements[strings.ToLower(r.tagName)]
+	case SelfClosingTag:
+		return blockElements[strings.ToLower(r.tagName)]
+	case Comment, procInst, flushNode:
+		return true
+	}
+	return false
+}
+
+// preformattedElements render their content's whitespace as is.
+var preformattedElements = map[string]bool{
+	"pre":       true,
+	"textarea":  true,
+	"listing":   true,
+	"plaintext": true,
+	"xmp":       true,
+	"script":    true,
+	"style":     true,
+	"title":     true,
+}
+
+// blockElements are the elements browsers lay out so that whitespace around
+// them is ignored: blocks, table parts, and elements that aren't displayed
+// at all. Everything else, including custom elements, is treated as inline.
+var blockElements = map[string]bool{
+	"address":    true,
+	"article":    true,
+	"aside":      true,
+	"base":       true,
+	"blockquote": true,
+	"body":       true,
+	"caption":    true,
+	"col":        true,
+	"colgroup":   true,
+	"datalist":   true,
+	"dd":         true,
+	"details":    true,
+	"dialog":     true,
+	"div":        true,
+	"dl":         true,
+	"dt":         true,
+	"fieldset":   true,
+	"figcaption": true,
+	"figure":     true,
+	"footer":     true,
+	"form":       true,
+	"h1":         true,
+	"h2":         true,
+	"h3":         true,
+	"h4":         true,
+	"h5":         true,
+	"h6":         true,
+	"head":       true,
+	"header":     true,
+	"hgroup":     true,
+	"hr":         true,
+	"html":       true,
+	"legend":     true,
+	"li":         true,
+	"link":       true,
+	"main":       true,
+	"menu":       true,
+	"meta":       true,
+	"nav":        true,
+	"ol":         true,
+	"optgroup":   true,
+	"option":     true,
+	"p":          true,
+	"pre":        true,
+	"script":     true,
+	"search":     true,
+	"section":    true,
+	"style":      true,
+	"summary":    true,
+	"table":      true,
+	"tbody":      true,
+	"td":         true,
+	"template":   true,
+	"tfoot":      true,
+	"th":         true,
+	"thead":      true,
+	"title":      true,
+	"tr":         true,
+	"ul":         true,
+}
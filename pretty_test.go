@@ -0,0 +1,105 @@
+package yahw
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestIndent(t *testing.T) {
+	tt := []struct {
+		Name string
+		Node Node
+		Exp  string
+	}{
+		{
+			Name: "Nested blocks",
+			Node: Div(ID("a"), Ul(Li(Text("x")), Li(Text("y")))),
+			Exp:  "<div id=\"a\">\n  <ul>\n    <li>x</li>\n    <li>y</li>\n  </ul>\n</div>",
+		},
+		{
+			Name: "Text stays inline",
+			Node: P(Text("a "), Strong(Text("b")), Text(" c")),
+			Exp:  "<p>a <strong>b</strong> c</p>",
+		},
+		{
+			Name: "Only inline elements",
+			Node: Div(Span(Text("a")), A(Href("/"), Text("b"))),
+			Exp:  "<div><span>a</span><a href=\"/\">b</a></div>",
+		},
+		{
+			Name: "Custom elements",
+			Node: Div(TagBuilder("x-a")(Text("a")), TagBuilder("x-a")(Div(Text("b")))),
+			Exp:  "<div><x-a>a</x-a><x-a><div>b</div></x-a></div>",
+		},
+		{
+			Name: "Slot and map",
+			Node: Section(TagBuilder("slot")(), TagBuilder("map")(Area())),
+			Exp:  "<section><slot></slot><map><area /></map></section>",
+		},
+		{
+			Name: "Table",
+			Node: Table(Tr(Td(Text("a")), Td(Text("b")))),
+			Exp:  "<table>\n  <tr>\n    <td>a</td>\n    <td>b</td>\n  </tr>\n</table>",
+		},
+		{
+			Name: "Block within inline",
+			Node: Span(Div(P(Text("a")))),
+			Exp:  "<span><div><p>a</p></div></span>",
+		},
+		{
+			Name: "Pre",
+			Node: Section(Pre(Div(Text("a")), Div(Text("b")))),
+			Exp:  "<section>\n  <pre><div>a</div><div>b</div></pre>\n</section>",
+		},
+		{
+			Name: "Textarea",
+			Node: Form(Textarea(Text("a\nb"))),
+			Exp:  "<form><textarea>a\nb</textarea></form>",
+		},
+		{
+			Name: "Void and comments",
+			Node: Head(Meta(Charset("utf-8")), Comment("c"), Link(Rel("icon"))),
+			Exp:  "<head>\n  <meta charset=\"utf-8\" />\n  <!--c-->\n  <link rel=\"icon\" />\n</head>",
+		},
+		{
+			Name: "Empty",
+			Node: Div(),
+			Exp:  "<div></div>",
+		},
+		{
+			Name: "Fragment",
+			Node: Fragment{P(Text("a")), P(Text("b"))},
+			Exp:  "<p>a</p>\n<p>b</p>",
+		},
+		{
+			Name: "Document",
+			Node: NewHTML5Doctype(HTML(Head(Title(Text("t"))), Body(Div(Text("x"))))),
+			Exp:  "<!DOCTYPE html>\n<html>\n  <head>\n    <title>t</title>\n  </head>\n  <body>\n    <div>x</div>\n  </body>\n</html>",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			strbuf := &strings.Builder{}
+			err := RenderTo(context.Background(), strbuf, tc.Node, Indent("  "))
+			if err != nil {
+				t.Fatalf("Error rendering: %s", err)
+			}
+			if strbuf.String() != tc.Exp {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tc.Exp, strbuf.String())
+			}
+		})
+	}
+}
+
+func TestIndentOff(t *testing.T) {
+	strbuf := &strings.Builder{}
+	err := RenderTo(context.Background(), strbuf, Div(Ul(Li(Text("x")))))
+	if err != nil {
+		t.Fatalf("Error rendering: %s", err)
+	}
+	if exp := "<div><ul><li>x</li></ul></div>"; strbuf.String() != exp {
+		t.Errorf("Expected %s, got %s", exp, strbuf.String())
+	}
+}
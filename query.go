@@ -0,0 +1,334 @@
+package yahw
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Query resolves node like Resolve and returns the elements matching the CSS
+// selector, in document order, e.g. Query(page, "form input[name=email]").
+// Type, universal, id, class and attribute selectors are supported, combined
+// with descendant and child combinators and in comma separated lists.
+// Pseudo-classes aren't. A *Tree is queried as it is, with the context it was
+// resolved with.
+func Query(node Node, selector string) ([]Renderable, error) {
+	sels, err := parseSelectors(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	if t, ok := node.(*Tree); ok && t.ctx != nil {
+		ctx = t.ctx
+	}
+	tree, err := Resolve(ctx, node)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Renderable
+	var ancestors []element
+	var visit func(r Renderable)
+	visit = func(r Renderable) {
+		if name, _ := elementOf(r); name != "" {
+			el := element{name: name, attrs: inspectAttrs(ctx, r)}
+			for _, sel := range sels {
+				if sel.match(len(sel)-1, ancestors, el) {
+					matches = append(matches, r)
+					break
+				}
+			}
+			ancestors = append(ancestors, el)
+			defer func() { ancestors = ancestors[:len(ancestors)-1] }()
+		}
+		for _, child := range childrenOf(r) {
+			if c := renderableOf(child); c != nil {
+				if _, ok := c.(attrable); !ok {
+					visit(c)
+				}
+			}
+		}
+	}
+	if tree.root != nil {
+		visit(tree.root)
+	}
+	return matches, nil
+}
+
+// element is what selectors are matched against.
+type element struct {
+	name  string
+	attrs []Attribute
+}
+
+// selector is a complex selector, a list of compound selectors joined by
+// combinators.
+type selector []compoundSelector
+
+type compoundSelector struct {
+	// combinator joins the selector to the one before it: ' ' for a
+	// descendant, '>' for a child.
+	combinator byte
+	// tag is empty for the universal selector.
+	tag     string
+	ids     []string
+	classes []string
+	attrs   []attrSelector
+}
+
+type attrSelector struct {
+	key, op, value string
+}
+
+// match reports whether sel[:i+1] matches el within ancestors.
+func (sel selector) match(i int, ancestors []element, el element) bool {
+	if !sel[i].matches(el) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	switch sel[i].combinator {
+	case '>':
+		if len(ancestors) == 0 {
+			return false
+		}
+		last := len(ancestors) - 1
+		return sel.match(i-1, ancestors[:last], ancestors[last])
+	default:
+		for k := len(ancestors) - 1; k >= 0; k-- {
+			if sel.match(i-1, ancestors[:k], ancestors[k]) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func (c compoundSelector) matches(el element) bool {
+	if c.tag != "" && c.tag != el.name {
+		return false
+	}
+	for _, id := range c.ids {
+		if v, ok := findAttr(el.attrs, "id"); !ok || v != id {
+			return false
+		}
+	}
+	if len(c.classes) > 0 {
+		v, _ := findAttr(el.attrs, "class")
+		classes := strings.Fields(v)
+		for _, cls := range c.classes {
+			if !slices.Contains(classes, cls) {
+				return false
+			}
+		}
+	}
+	for _, a := range c.attrs {
+		v, ok := findAttr(el.attrs, a.key)
+		if !ok || !a.matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a attrSelector) matches(v string) bool {
+	switch a.op {
+	case "":
+		return true
+	case "=":
+		return v == a.value
+	case "~=":
+		return slices.Contains(strings.Fields(v), a.value)
+	case "|=":
+		return v == a.value || strings.HasPrefix(v, a.value+"-")
+	case "^=":
+		return a.value != "" && strings.HasPrefix(v, a.value)
+	case "$=":
+		return a.value != "" && strings.HasSuffix(v, a.value)
+	case "*=":
+		return a.value != "" && strings.Contains(v, a.value)
+	}
+	return false
+}
+
+// selectorParser parses a selector list.
+type selectorParser struct {
+	src string
+	pos int
+}
+
+func parseSelectors(src string) ([]selector, error) {
+	p := &selectorParser{src: src}
+	var sels []selector
+	for {
+		sel, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+		if p.pos == len(p.src) {
+			return sels, nil
+		}
+		p.pos++ // The comma.
+	}
+}
+
+func (p *selectorParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w %q at offset %d: %s", ErrInvalidSelector, p.src, p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *selectorParser) skipSpace() bool {
+	start := p.pos
+	for p.pos < len(p.src) && strings.IndexByte(" \t\n\r\f", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+	return p.pos > start
+}
+
+// parseSelector parses a complex selector, up to a comma or the end.
+func (p *selectorParser) parseSelector() (selector, error) {
+	var sel selector
+	var combinator byte
+	p.skipSpace()
+	for {
+		compound, err := p.parseCompound()
+		if err != nil {
+			return nil, err
+		}
+		compound.combinator = combinator
+		sel = append(sel, compound)
+
+		space := p.skipSpace()
+		if p.pos == len(p.src) || p.src[p.pos] == ',' {
+			return sel, nil
+		}
+		combinator = ' '
+		if p.src[p.pos] == '>' {
+			combinator = '>'
+			p.pos++
+			p.skipSpace()
+		} else if !space {
+			return nil, p.errorf("unexpected %q", p.src[p.pos])
+		}
+		if p.pos == len(p.src) || p.src[p.pos] == ',' {
+			return nil, p.errorf("missing selector after combinator")
+		}
+	}
+}
+
+func (p *selectorParser) parseCompound() (compoundSelector, error) {
+	var c compoundSelector
+	start := p.pos
+	if p.pos < len(p.src) && p.src[p.pos] == '*' {
+		p.pos++
+	} else if name := p.parseIdent(); name != "" {
+		c.tag = strings.ToLower(name)
+	}
+
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '#', '.':
+			kind := p.src[p.pos]
+			p.pos++
+			name := p.parseIdent()
+			if name == "" {
+				return c, p.errorf("missing name after %q", kind)
+			}
+			if kind == '#' {
+				c.ids = append(c.ids, name)
+			} else {
+				c.classes = append(c.classes, name)
+			}
+		case '[':
+			attr, err := p.parseAttr()
+			if err != nil {
+				return c, err
+			}
+			c.attrs = append(c.attrs, attr)
+		case ':':
+			return c, p.errorf("pseudo-classes aren't supported")
+		default:
+			if p.pos == start {
+				return c, p.errorf("unexpected %q", p.src[p.pos])
+			}
+			return c, nil
+		}
+	}
+	if p.pos == start {
+		return c, p.errorf("missing selector")
+	}
+	return c, nil
+}
+
+func (p *selectorParser) parseAttr() (attrSelector, error) {
+	var a attrSelector
+	p.pos++ // The opening bracket.
+	p.skipSpace()
+	a.key = p.parseIdent()
+	if a.key == "" {
+		return a, p.errorf("missing attribute name")
+	}
+	p.skipSpace()
+
+	for _, op := range []string{"=", "~=", "|=", "^=", "$=", "*="} {
+		if strings.HasPrefix(p.src[p.pos:], op) {
+			a.op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if a.op != "" {
+		p.skipSpace()
+		value, err := p.parseValue()
+		if err != nil {
+			return a, err
+		}
+		a.value = value
+		p.skipSpace()
+	}
+
+	if p.pos == len(p.src) || p.src[p.pos] != ']' {
+		return a, p.errorf("missing ]")
+	}
+	p.pos++
+	return a, nil
+}
+
+func (p *selectorParser) parseValue() (string, error) {
+	if p.pos < len(p.src) && (p.src[p.pos] == '"' || p.src[p.pos] == '\'') {
+		quote := p.src[p.pos]
+		end := strings.IndexByte(p.src[p.pos+1:], quote)
+		if end < 0 {
+			return "", p.errorf("unterminated string")
+		}
+		value := p.src[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return value, nil
+	}
+	value := p.parseIdent()
+	if value == "" {
+		return "", p.errorf("missing attribute value")
+	}
+	return value, nil
+}
+
+// parseIdent parses a name made of letters, digits, hyphens and underscores.
+func (p *selectorParser) parseIdent() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		if !isIdentRune(r) {
+			break
+		}
+		p.pos += size
+	}
+	return p.src[start:p.pos]
+}
+
+func isIdentRune(r rune) bool {
+	return isASCIILetter(r) || ('0' <= r && r <= '9') || r == '-' || r == '_' || r >= utf8.RuneSelf
+}
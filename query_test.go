@@ -0,0 +1,100 @@
+package yahw
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	page := Body(
+		Form(ID("signup"), Classes("form wide"),
+			Label(Text("Email"), Input(Name("email"), Type("email"), Lang("en-US"))),
+			Input(Name("password"), Type("password")),
+		),
+		Section(Classes("wide"), Input(Name("email")), Ul(Li(A(Href("https://example.com/a.pdf"))))),
+		nodeFunc(func(ctx context.Context) Renderable { return P(ID("late"), Classes("note")) }),
+	)
+
+	for _, tc := range []struct {
+		Selector string
+		Exp      []string
+	}{
+		{Selector: "form input[name=email]", Exp: []string{"email"}},
+		{Selector: "input[name=email]", Exp: []string{"email", "email"}},
+		{Selector: "form > input", Exp: []string{"password"}},
+		{Selector: "body > * > input", Exp: []string{"password", "email"}},
+		{Selector: "#signup input", Exp: []string{"email", "password"}},
+		{Selector: ".wide > input", Exp: []string{"password", "email"}},
+		{Selector: "form.form.wide input[type='password']", Exp: []string{"password"}},
+		{Selector: "form.narrow input", Exp: nil},
+		{Selector: "[lang|=en]", Exp: []string{"email"}},
+		{Selector: "[class~=wide]", Exp: []string{"form", "section"}},
+		{Selector: "a[href^=https][href$='.pdf'][href*=example]", Exp: []string{"a"}},
+		{Selector: "INPUT[type]", Exp: []string{"email", "password"}},
+		{Selector: "p#late.note, form", Exp: []string{"form", "p"}},
+		{Selector: "section li a, body > section", Exp: []string{"section", "a"}},
+	} {
+		matches, err := Query(page, tc.Selector)
+		if err != nil {
+			t.Errorf("%s: %s", tc.Selector, err)
+			continue
+		}
+		var got []string
+		for _, r := range matches {
+			tag := r.(interface {
+				TagName() string
+				Attr(string) (string, bool)
+			})
+			name, ok := tag.Attr("name")
+			if !ok {
+				name = tag.TagName()
+			}
+			got = append(got, name)
+		}
+		if strings.Join(got, " ") != strings.Join(tc.Exp, " ") {
+			t.Errorf("%s: expected %v, got %v", tc.Selector, tc.Exp, got)
+		}
+	}
+}
+
+func TestQueryTree(t *testing.T) {
+	tree, err := Resolve(context.Background(), Div(Input(ID("q")), Input(ID("q"))))
+	if err != nil {
+		t.Fatalf("Error resolving: %s", err)
+	}
+	matches, err := Query(DedupeIDs(tree), "#q-2")
+	if err != nil {
+		t.Fatalf("Error querying: %s", err)
+	}
+	if len(matches) != 1 {
+		t.Errorf("Expected a match, got %v", matches)
+	}
+}
+
+func TestQueryInvalidSelector(t *testing.T) {
+	for _, selector := range []string{
+		"",
+		"div,",
+		"div >",
+		"> div",
+		"div:first-child",
+		"div[",
+		"div[name",
+		"div[name=]",
+		"div[name='x]",
+		"div[=x]",
+		"div.",
+		"div#",
+		"div + p",
+	} {
+		if _, err := Query(Div(), selector); !errors.Is(err, ErrInvalidSelector) {
+			t.Errorf("%q: expected ErrInvalidSelector, got %v", selector, err)
+		}
+	}
+
+	if _, err := Query(Div(CommonTag{tagName: "b c"}), "div"); err == nil {
+		t.Errorf("Expected an error for an invalid tree")
+	}
+}
@@ -18,7 +18,7 @@ func (r Raw) tag()                                {}
 func (r Raw) Node(ctx context.Context) Renderable { return r }
 
 func (r Raw) Render(ctx context.Context, w io.Writer) error {
-	_, err := w.Write([]byte(r))
+	_, err := io.WriteString(w, string(r))
 	return err
 }
 
@@ -0,0 +1,175 @@
+package yahw
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"sync"
+)
+
+const defaultBufferSize = 4096
+
+var bufferPool = sync.Pool{
+	New: func() any { return bufio.NewWriterSize(nil, defaultBufferSize) },
+}
+
+type renderConfig struct {
+	bufferSize int
+	indent     string
+	minify     bool
+	syntax     Syntax
+}
+
+// RenderOption configures RenderTo.
+type RenderOption func(*renderConfig)
+
+// BufferSize sets the size of the buffer output is collected in before it's
+// written to the underlying writer.
+func BufferSize(size int) RenderOption {
+	return func(c *renderConfig) {
+		c.bufferSize = size
+	}
+}
+
+// renderWriter buffers writes to w. Once a write fails, every following write
+// returns the same error, so renderers don't have to check each one.
+type renderWriter struct {
+	w   io.Writer
+	buf *bufio.Writer
+	err error
+}
+
+func (rw *renderWriter) Write(p []byte) (int, error) {
+	if rw.err != nil {
+		return 0, rw.err
+	}
+	n, err := rw.buf.Write(p)
+	rw.err = err
+	return n, err
+}
+
+func (rw *renderWriter) WriteString(s string) (int, error) {
+	if rw.err != nil {
+		return 0, rw.err
+	}
+	n, err := rw.buf.WriteString(s)
+	rw.err = err
+	return n, err
+}
+
+// Flush writes the buffered output to the underlying writer and, if it's an
+// http.Flusher, sends it to the client.
+func (rw *renderWriter) Flush() error {
+	if rw.err != nil {
+		return rw.err
+	}
+	rw.err = rw.buf.Flush()
+	if rw.err != nil {
+		return rw.err
+	}
+	if f, ok := rw.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// RenderTo renders node to w. Output is buffered and only written to w once
+// the buffer fills up, at Flush nodes and when rendering is done.
+//
+// It's Resolve followed by Serialize, so components are resolved before
+// anything is written and what they register with UseCSS, SetTitle, AddMeta,
+// AddLink and AddScript can be rendered in the head of the page.
+func RenderTo(ctx context.Context, w io.Writer, node Node, opts ...RenderOption) error {
+	if node == nil {
+		return nil
+	}
+	// Whether nodes are valid can depend on the syntax, so it's known while
+	// resolving too.
+	cfg := renderConfig{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.syntax != PolyglotSyntax {
+		ctx = withSyntax(ctx, cfg.syntax)
+	}
+	tree, err := Resolve(ctx, node)
+	if err != nil {
+		return err
+	}
+	return Serialize(tree, w, opts...)
+}
+
+// Serialize writes tree to w. Output is buffered like with RenderTo.
+func Serialize(tree *Tree, w io.Writer, opts ...RenderOption) error {
+	cfg := renderConfig{bufferSize: defaultBufferSize}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	var buf *bufio.Writer
+	if cfg.bufferSize == defaultBufferSize {
+		buf = bufferPool.Get().(*bufio.Writer)
+		defer func() {
+			buf.Reset(nil)
+			bufferPool.Put(buf)
+		}()
+		buf.Reset(w)
+	} else {
+		buf = bufio.NewWriterSize(w, cfg.bufferSize)
+	}
+
+	ctx := tree.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	rw := &renderWriter{w: w, buf: buf}
+	if cfg.indent != "" {
+		ctx = withLayout(ctx, layout{indent: cfg.indent})
+	}
+	if cfg.minify {
+		ctx = withMinify(ctx, minifyState{})
+	}
+	if cfg.syntax != PolyglotSyntax {
+		ctx = withSyntax(ctx, cfg.syntax)
+	}
+	if tree.root != nil {
+		if err := tree.root.Render(ctx, rw); err != nil {
+			return err
+		}
+	}
+	if rw.err != nil {
+		return rw.err
+	}
+	rw.err = rw.buf.Flush()
+	return rw.err
+}
+
+type flushNode struct{}
+
+func (f flushNode) tag()                                {}
+func (f flushNode) Node(ctx context.Context) Renderable { return f }
+
+func (f flushNode) Render(ctx context.Context, w io.Writer) error {
+	switch w := w.(type) {
+	case interface{ Flush() error }:
+		return w.Flush()
+	case http.Flusher:
+		w.Flush()
+	}
+	return nil
+}
+
+// Flush sends everything rendered so far to the client, e.g. after the head
+// so the browser can start loading stylesheets and scripts early.
+func Flush() Node { return flushNode{} }
+
+// writeStrings writes all strings to w, stopping at the first error.
+func writeStrings(w io.Writer, ss ...string) error {
+	for _, s := range ss {
+		if _, err := io.WriteString(w, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
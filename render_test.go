@@ -0,0 +1,97 @@
+package yahw
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type failingWriter struct {
+	writes int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	w.writes++
+	return 0, errors.New("write failed")
+}
+
+// flushRecorder records what was written by the time each flush happened.
+type flushRecorder struct {
+	strings.Builder
+	flushed []string
+}
+
+func (r *flushRecorder) Flush() { r.flushed = append(r.flushed, r.String()) }
+
+func TestRenderTo(t *testing.T) {
+	strbuf := &strings.Builder{}
+	err := RenderTo(context.Background(), strbuf, Div(ID("x"), P(Text("a & b"))))
+	if err != nil {
+		t.Fatalf("Error rendering: %s", err)
+	}
+	if exp := `<div id="x"><p>a &amp; b</p></div>`; strbuf.String() != exp {
+		t.Errorf("Expected %s, got %s", exp, strbuf.String())
+	}
+}
+
+func TestRenderToLargeOutput(t *testing.T) {
+	items := Nodes{}
+	for i := 0; i < 1000; i++ {
+		items = append(items, Li(Text("item")))
+	}
+
+	for _, size := range []int{16, defaultBufferSize} {
+		strbuf := &strings.Builder{}
+		err := RenderTo(context.Background(), strbuf, Ul(items), BufferSize(size))
+		if err != nil {
+			t.Fatalf("Error rendering: %s", err)
+		}
+		exp := "<ul>" + strings.Repeat("<li>item</li>", 1000) + "</ul>"
+		if strbuf.String() != exp {
+			t.Errorf("Unexpected output with buffer size %d", size)
+		}
+	}
+}
+
+func TestRenderToWriteError(t *testing.T) {
+	w := &failingWriter{}
+	err := RenderTo(context.Background(), w, Div(Text(strings.Repeat("x", 3*defaultBufferSize))))
+	if err == nil {
+		t.Fatalf("Expected error, got nil")
+	}
+	if w.writes != 1 {
+		t.Errorf("Expected writing to stop after the first error, got %d writes", w.writes)
+	}
+}
+
+func TestRenderToFlush(t *testing.T) {
+	rec := &flushRecorder{}
+	err := RenderTo(context.Background(), rec, HTML(
+		Head(Title(Text("Hi"))),
+		Flush(),
+		Body(Text("content")),
+	))
+	if err != nil {
+		t.Fatalf("Error rendering: %s", err)
+	}
+
+	if len(rec.flushed) != 1 || rec.flushed[0] != "<html><head><title>Hi</title></head>" {
+		t.Errorf("Unexpected flushes: %q", rec.flushed)
+	}
+	if exp := "<html><head><title>Hi</title></head><body>content</body></html>"; rec.String() != exp {
+		t.Errorf("Expected %s, got %s", exp, rec.String())
+	}
+}
+
+func TestFlushWithResponseWriter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	err := Div(Flush()).Render(context.Background(), rec)
+	if err != nil {
+		t.Fatalf("Error rendering: %s", err)
+	}
+	if !rec.Flushed {
+		t.Errorf("Expected response to be flushed")
+	}
+}
@@ -0,0 +1,297 @@
+package yahw
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Tree is a page whose components have all been resolved into tags, ready to
+// be serialized. Passes like DedupeIDs and ValidateLinks work on it between
+// resolving and serializing. It can't be changed; passes return a copy.
+type Tree struct {
+	// ctx is the context the tree was resolved with. It's used again for
+	// serializing, e.g. for merge policies and URL schemes.
+	ctx  context.Context
+	root Renderable
+}
+
+// Resolve calls the Node method of every component within node, which is
+// when they register anything with UseCSS, SetTitle and the like, and adds
+// what they registered to the head. Attributes are merged according to the
+// merge policies in ctx. Invalid tags and attributes, including conflicts
+// with WithStrictAttrs, are reported as *RenderError before anything is
+// serialized.
+func Resolve(ctx context.Context, node Node) (*Tree, error) {
+	doc := &document{}
+	resolveCtx := withDocument(ctx, doc)
+
+	var root Renderable
+	if node != nil {
+		root = node.Node(resolveCtx)
+	}
+	if root == nil {
+		return &Tree{ctx: ctx}, nil
+	}
+	root, err := resolveTree(resolveCtx, root)
+	if err != nil {
+		return nil, err
+	}
+	return &Tree{ctx: ctx, root: addToHead(resolveCtx, root, doc)}, nil
+}
+
+// Root returns the outermost node of the tree, or nil if it's empty.
+func (t *Tree) Root() Renderable { return t.root }
+
+// Node implements Node, so a tree can be rendered and queried like any
+// other node.
+func (t *Tree) Node(ctx context.Context) Renderable { return t.root }
+
+// resolveTree calls the Node method of every component within r and returns
+// the tree of tags they build, with the attributes of each tag merged in
+// front of its children. Anything components register in ctx, like CSS, is
+// known once it returns, before anything is rendered. Invalid tags are
+// reported as they would be when rendering.
+func resolveTree(ctx context.Context, r Renderable) (Renderable, error) {
+	switch t := r.(type) {
+	case CommonTag:
+		if !isValidTagName(t.tagName) {
+			return nil, newRenderError(t.tagName, fmt.Errorf("%w: %q", ErrInvalidTagName, t.tagName))
+		}
+		attrs := AttrSlice{}
+		tags := []taggable{}
+		for _, n := range unwrapNodes(ctx, t.children) {
+			switch child := n.(type) {
+			case nil:
+			case attrable:
+				attrs = append(attrs, child)
+			case taggable:
+				tags = append(tags, child)
+			default:
+				return nil, newRenderError(t.tagName, fmt.Errorf("%w: %T", ErrInvalidNode, n))
+			}
+		}
+		merged, err := mergeAttrs(ctx, attrs)
+		if err != nil {
+			return nil, newRenderError(t.tagName, err)
+		}
+
+		children := make([]Node, 0, len(merged)+len(tags))
+		for _, attr := range merged {
+			children = append(children, asNode(attr))
+		}
+		childCtx := withParentTag(ctx, t.tagName)
+		for idx, child := range tags {
+			r, err := resolveTree(childCtx, child)
+			if _, ok := err.(*RenderError); err != nil && !ok {
+				// Nodes other than tags are reported at the enclosing tag.
+				return nil, newRenderError(t.tagName, err)
+			}
+			if err != nil {
+				return nil, wrapChildError(t.tagName, tags, idx, err)
+			}
+			children = append(children, asNode(r))
+		}
+		return CommonTag{tagName: t.tagName, children: children}, nil
+	case SelfClosingTag:
+		if !isValidTagName(t.tagName) {
+			return nil, newRenderError(t.tagName, fmt.Errorf("%w: %q", ErrInvalidTagName, t.tagName))
+		}
+		merged, err := mergeAttrs(ctx, t.attrs)
+		if err != nil {
+			return nil, newRenderError(t.tagName, err)
+		}
+		return SelfClosingTag{tagName: t.tagName, attrs: merged}, nil
+	case Fragment:
+		children, err := resolveNodes(ctx, t)
+		return Fragment(children), err
+	case TagSlice:
+		children, err := resolveNodes(ctx, t)
+		return TagSlice(children), err
+	case HTML5Doctype:
+		children, err := resolveNodes(ctx, t.children)
+		return HTML5Doctype{children: children}, err
+	case CDATA:
+		return t, t.validate(ctx)
+	case procInst:
+		return t, t.validate(ctx)
+	case conditionalComment:
+		if err := t.validate(); err != nil {
+			return nil, err
+		}
+		children, err := resolveNodes(ctx, t.children)
+		if err != nil {
+			return nil, err
+		}
+		t.children = children
+		if !t.revealed {
+			if _, err := t.content(ctx); err != nil {
+				return nil, err
+			}
+		}
+		return t, nil
+	}
+	return r, nil
+}
+
+// resolveNodes resolves nodes that aren't within a tag.
+func resolveNodes(ctx context.Context, nodes []Node) ([]Node, error) {
+	unwrapped := unwrapNodes(ctx, nodes)
+	resolved := make([]Node, 0, len(unwrapped))
+	for _, r := range unwrapped {
+		switch r.(type) {
+		case nil:
+			continue
+		case attrable:
+			return nil, fmt.Errorf("%w: attribute %T outside of a tag", ErrInvalidNode, r)
+		}
+		r, err := resolveTree(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, asNode(r))
+	}
+	return resolved, nil
+}
+
+// resolvedNode makes a Renderable usable as a Node.
+type resolvedNode struct {
+	r Renderable
+}
+
+func (n resolvedNode) Node(ctx context.Context) Renderable { return n.r }
+
+func asNode(r Renderable) Node {
+	if n, ok := r.(Node); ok {
+		return n
+	}
+	return resolvedNode{r: r}
+}
+
+// addToHead fills the first head element within the resolved tree r with
+// what components registered in doc. If there's no head, it's all put in
+// front of r.
+func addToHead(ctx context.Context, r Renderable, doc *document) Renderable {
+	if doc.empty() {
+		return r
+	}
+	if withHead, ok := fillHead(ctx, r, doc); ok {
+		return withHead
+	}
+	return Fragment(append(doc.fillHead(ctx, nil), asNode(r)))
+}
+
+func fillHead(ctx context.Context, r Renderable, doc *document) (Renderable, bool) {
+	switch t := r.(type) {
+	case CommonTag:
+		if strings.EqualFold(t.tagName, "head") {
+			return CommonTag{tagName: t.tagName, children: doc.fillHead(ctx, t.children)}, true
+		}
+		children, ok := fillHeadWithin(ctx, t.children, doc)
+		return CommonTag{tagName: t.tagName, children: children}, ok
+	case Fragment:
+		children, ok := fillHeadWithin(ctx, t, doc)
+		return Fragment(children), ok
+	case TagSlice:
+		children, ok := fillHeadWithin(ctx, t, doc)
+		return TagSlice(children), ok
+	case HTML5Doctype:
+		children, ok := fillHeadWithin(ctx, t.children, doc)
+		return HTML5Doctype{children: children}, ok
+	}
+	return r, false
+}
+
+func fillHeadWithin(ctx context.Context, children []Node, doc *document) ([]Node, bool) {
+	for i, child := range children {
+		n, ok := child.(Renderable)
+		if !ok {
+			continue
+		}
+		withHead, ok := fillHead(ctx, n, doc)
+		if ok {
+			res := append([]Node{}, children...)
+			res[i] = asNode(withHead)
+			return res, true
+		}
+	}
+	return children, false
+}
+
+// childrenOf returns the child nodes of r, including attributes.
+func childrenOf(r Renderable) []Node {
+	switch t := r.(type) {
+	case CommonTag:
+		return t.children
+	case Fragment:
+		return t
+	case TagSlice:
+		return t
+	case HTML5Doctype:
+		return t.children
+	case conditionalComment:
+		return t.children
+	}
+	return nil
+}
+
+// withChildren returns a copy of r with children in place of its own.
+func withChildren(r Renderable, children []Node) Renderable {
+	switch t := r.(type) {
+	case CommonTag:
+		return CommonTag{tagName: t.tagName, children: children}
+	case Fragment:
+		return Fragment(children)
+	case TagSlice:
+		return TagSlice(children)
+	case HTML5Doctype:
+		return HTML5Doctype{children: children}
+	case conditionalComment:
+		t.children = children
+		return t
+	}
+	return r
+}
+
+// renderableOf returns what the resolved node n renders.
+func renderableOf(n Node) Renderable {
+	switch t := n.(type) {
+	case resolvedNode:
+		return t.r
+	case Renderable:
+		return t
+	}
+	return nil
+}
+
+// walkTree calls fn for r and everything within it except attributes, in
+// document order.
+func walkTree(r Renderable, fn func(Renderable)) {
+	walkTreeUntil(r, func(r Renderable) bool {
+		fn(r)
+		return true
+	})
+}
+
+// mapTree returns a copy of r with fn applied to r and everything within it
+// except attributes, in document order. fn sees a node before its children.
+func mapTree(r Renderable, fn func(Renderable) Renderable) Renderable {
+	if r == nil {
+		return nil
+	}
+	r = fn(r)
+	children := childrenOf(r)
+	if children == nil {
+		return r
+	}
+	mapped := make([]Node, len(children))
+	for i, child := range children {
+		mapped[i] = child
+		if c := renderableOf(child); c != nil {
+			if _, ok := c.(attrable); !ok {
+				mapped[i] = asNode(mapTree(c, fn))
+			}
+		}
+	}
+	return withChildren(r, mapped)
+}
@@ -0,0 +1,163 @@
+package yahw
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type counter struct {
+	calls *int
+}
+
+func (c counter) Node(ctx context.Context) Renderable {
+	*c.calls++
+	return Span(Text("x"))
+}
+
+func TestResolve(t *testing.T) {
+	calls := 0
+	tree, err := Resolve(context.Background(), Div(AttrSlice{ID("a"), If(true, Classes("b"))}, counter{calls: &calls}))
+	if err != nil {
+		t.Fatalf("Error resolving: %s", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the component to be resolved once, got %d", calls)
+	}
+
+	for _, tc := range []struct {
+		Opts []RenderOption
+		Exp  string
+	}{
+		{Exp: `<div id="a" class="b"><span>x</span></div>`},
+		{Opts: []RenderOption{Minify()}, Exp: `<div id=a class=b><span>x</span></div>`},
+	} {
+		strbuf := &strings.Builder{}
+		if err := Serialize(tree, strbuf, tc.Opts...); err != nil {
+			t.Fatalf("Error serializing: %s", err)
+		}
+		if strbuf.String() != tc.Exp {
+			t.Errorf("Expected %s, got %s", tc.Exp, strbuf.String())
+		}
+	}
+	if calls != 1 {
+		t.Errorf("Expected serializing not to resolve components again, got %d calls", calls)
+	}
+}
+
+func TestResolveMergesAttributes(t *testing.T) {
+	tree, err := Resolve(context.Background(), Div(AttrSlice{ID("a"), AttrSlice{Classes("b")}}, If(false, Title(Text("x"))).Else(HiddenIf(true)), Classes("c b"), Text("y")))
+	if err != nil {
+		t.Fatalf("Error resolving: %s", err)
+	}
+	div := tree.Root().(CommonTag)
+	exp := []Node{ID("a"), Classes("b c"), NoValAttribute{key: "hidden"}, Text("y")}
+	if len(div.children) != len(exp) {
+		t.Fatalf("Expected %d children, got %#v", len(exp), div.children)
+	}
+	for i := range exp {
+		if div.children[i] != exp[i] {
+			t.Errorf("Expected %#v, got %#v", exp[i], div.children[i])
+		}
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	_, err := Resolve(context.Background(), HTML(Body(Div(), Div(Span(untagged{})))))
+	var re *RenderError
+	if !errors.As(err, &re) || !errors.Is(err, ErrInvalidNode) {
+		t.Fatalf("Expected a RenderError, got %v", err)
+	}
+	if path := strings.Join(re.Path, " > "); path != "html > body > div[2] > span" {
+		t.Errorf("Expected path html > body > div[2] > span, got %s", path)
+	}
+
+	strbuf := &strings.Builder{}
+	err = RenderTo(context.Background(), strbuf, Div(P(Text("x")), Fragment{P(), NewTag("a b")}))
+	if !errors.Is(err, ErrInvalidTagName) {
+		t.Errorf("Expected ErrInvalidTagName, got %v", err)
+	}
+	if strbuf.Len() > 0 {
+		t.Errorf("Expected nothing to be written, got %s", strbuf.String())
+	}
+}
+
+func TestResolveAttributeErrors(t *testing.T) {
+	long := P(Text(strings.Repeat("x", 5000)))
+	tt := []struct {
+		Name string
+		Ctx  context.Context
+		Node Node
+		Err  error
+	}{
+		{Name: "Strict conflict", Ctx: WithStrictAttrs(context.Background()), Node: Div(long, Span(ID("a"), ID("b"))), Err: &AttrConflictError{}},
+		{Name: "Styles", Ctx: context.Background(), Node: Div(long, Span(Styles{"a b": "x"})), Err: ErrInvalidStyleProperty},
+		{Name: "Merged styles", Ctx: context.Background(), Node: Div(long, Img(StyleAttr("color: red"), Styles{"a b": "x"})), Err: ErrInvalidStyleProperty},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := Resolve(tc.Ctx, tc.Node)
+			var re *RenderError
+			if !errors.As(err, &re) {
+				t.Fatalf("Expected a *RenderError, got %v", err)
+			}
+			if conflict := (*AttrConflictError)(nil); errors.As(tc.Err, &conflict) {
+				if !errors.As(err, &conflict) {
+					t.Errorf("Expected an *AttrConflictError, got %v", err)
+				}
+			} else if !errors.Is(err, tc.Err) {
+				t.Errorf("Expected %v, got %v", tc.Err, err)
+			}
+
+			strbuf := &strings.Builder{}
+			if err := RenderTo(tc.Ctx, strbuf, tc.Node); err == nil || strbuf.Len() > 0 {
+				t.Errorf("Expected an error before any output, got %v after %d bytes", err, strbuf.Len())
+			}
+		})
+	}
+}
+
+func TestTreeHasMergedAttributes(t *testing.T) {
+	tree, err := Resolve(context.Background(), Div(
+		Span(ID("a"), ID("b")),
+		Input(BuildAttr("aria-describedby", "x"), BuildAttr("aria-describedby", "y")),
+		P(ID("x"), Classes("c"), ClassesMap{"d": true}),
+	))
+	if err != nil {
+		t.Fatalf("Error resolving: %s", err)
+	}
+
+	var got []string
+	walkTree(tree.root, func(r Renderable) {
+		_, attrs := elementOf(r)
+		for _, attr := range attrs {
+			if named, ok := attr.(namedAttr); ok {
+				got = append(got, named.attrKey()+"="+named.attrValue())
+			}
+		}
+	})
+	if exp := "id=b aria-describedby=x y id=x class=c d"; strings.Join(got, " ") != exp {
+		t.Errorf("Expected %s, got %s", exp, strings.Join(got, " "))
+	}
+
+	var blerr *BrokenLinkError
+	if err := ValidateLinks(tree); !errors.As(err, &blerr) || blerr.ID != "y" {
+		t.Errorf("Expected a broken link to y, got %v", err)
+	}
+}
+
+func TestSerializeEmptyTree(t *testing.T) {
+	tree, err := Resolve(context.Background(), nodeFunc(func(ctx context.Context) Renderable { return nil }))
+	if err != nil {
+		t.Fatalf("Error resolving: %s", err)
+	}
+	if tree.Root() != nil {
+		t.Errorf("Expected an empty tree, got %#v", tree.Root())
+	}
+	strbuf := &strings.Builder{}
+	if err := Serialize(tree, strbuf); err != nil || strbuf.Len() > 0 {
+		t.Errorf("Expected no output, got %q, %v", strbuf.String(), err)
+	}
+}
@@ -0,0 +1,148 @@
+package yahw
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// InvalidCSS replaces style values that could do more than set a property,
+// like "red; background-image: url(...)". Browsers drop the declaration.
+const InvalidCSS = "yahw-invalid"
+
+// Styles is a style attribute built from CSS properties and their values.
+// Declarations are rendered sorted by property, and values that aren't plain
+// CSS values are replaced with InvalidCSS. Like Classes, it's merged with
+// other style attributes on the same tag.
+type Styles map[string]string
+
+var (
+	_ Node      = Styles{}
+	_ attrable  = Styles{}
+	_ namedAttr = Styles{}
+)
+
+func (s Styles) attr()                               {}
+func (s Styles) Node(ctx context.Context) Renderable { return s }
+
+func (s Styles) Render(ctx context.Context, w io.Writer) error {
+	if err := s.validate(); err != nil {
+		return err
+	}
+	return writeAttr(ctx, w, "style", s.extract())
+}
+
+func (s Styles) attrKey() string   { return "style" }
+func (s Styles) attrValue() string { return s.extract() }
+
+// Set returns a copy of s with prop set to value.
+func (s Styles) Set(prop, value string) Styles {
+	newMap := maps.Clone(s)
+	if newMap == nil {
+		newMap = Styles{}
+	}
+	newMap[prop] = value
+	return newMap
+}
+
+func (s Styles) validate() error {
+	for prop := range s {
+		if !isValidCSSProperty(prop) {
+			return fmt.Errorf("%w: %q", ErrInvalidStyleProperty, prop)
+		}
+	}
+	return nil
+}
+
+// extract renders the declarations, leaving out invalid properties.
+func (s Styles) extract() string {
+	props := make([]string, 0, len(s))
+	for prop := range s {
+		if isValidCSSProperty(prop) {
+			props = append(props, prop)
+		}
+	}
+	slices.Sort(props)
+
+	decls := make([]string, len(props))
+	for i, prop := range props {
+		decls[i] = prop + ": " + sanitizeCSSValue(s[prop]) + ";"
+	}
+	return strings.Join(decls, " ")
+}
+
+// unsafeCSSProperties run code or load resources whatever their value.
+var unsafeCSSProperties = []string{"behavior", "-moz-binding"}
+
+// isValidCSSProperty reports whether prop is a property name like "color",
+// "-webkit-box-shadow" or "--custom".
+func isValidCSSProperty(prop string) bool {
+	if slices.Contains(unsafeCSSProperties, strings.ToLower(prop)) {
+		return false
+	}
+	name := strings.TrimPrefix(prop, "-")
+	custom := strings.HasPrefix(name, "-")
+	if custom {
+		name = name[1:]
+	}
+	if name == "" || (!custom && !isASCIILetter(rune(name[0]))) {
+		return false
+	}
+	for _, r := range name {
+		if !isASCIILetter(r) && !('0' <= r && r <= '9') && r != '-' && !(custom && r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCIILetter(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z')
+}
+
+// unsafeCSSFunctions load resources or run code.
+var unsafeCSSFunctions = []string{
+	"url(", "image(", "image-set(", "-webkit-image-set(", "src(",
+	"cross-fade(", "element(", "expression(",
+}
+
+// sanitizeCSSValue returns value if it can only be a property value: it
+// can't end the declaration, open a block or a comment, use escapes or load
+// anything. Quoted strings have to be closed.
+func sanitizeCSSValue(value string) string {
+	var quote rune
+	for _, r := range value {
+		switch {
+		case quote != 0:
+			switch r {
+			case quote:
+				quote = 0
+			case '\\', '\n', '\r', '\f', ';':
+				return InvalidCSS
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune(" \t#%.,+-*/()_", r):
+		default:
+			return InvalidCSS
+		}
+	}
+	if quote != 0 {
+		return InvalidCSS
+	}
+
+	if strings.Contains(value, "/*") {
+		return InvalidCSS
+	}
+	lower := strings.ToLower(value)
+	for _, fn := range unsafeCSSFunctions {
+		if strings.Contains(lower, fn) {
+			return InvalidCSS
+		}
+	}
+	return value
+}
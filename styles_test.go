@@ -0,0 +1,54 @@
+package yahw
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStyles(t *testing.T) {
+	tt := []struct {
+		Name string
+		Node Renderable
+		Exp  string
+	}{
+		{Name: "Sorted", Node: Styles{"margin": "0 auto", "color": "red"}, Exp: `style="color: red; margin: 0 auto;"`},
+		{Name: "Empty", Node: Styles{}, Exp: `style=""`},
+		{Name: "Functions", Node: Styles{"width": "calc(100% - 2px)", "color": "rgb(0, 0, 0)"}, Exp: `style="color: rgb(0, 0, 0); width: calc(100% - 2px);"`},
+		{Name: "Custom and vendor properties", Node: Styles{"--main_color": "#fff", "-webkit-box-shadow": "none"}, Exp: `style="--main_color: #fff; -webkit-box-shadow: none;"`},
+		{Name: "Quoted string", Node: Styles{"font-family": `"Helvetica Neue", sans-serif`}, Exp: `style="font-family: &#34;Helvetica Neue&#34;, sans-serif;"`},
+		{Name: "Injected declaration", Node: Styles{"color": "red; background-image: url(x)"}, Exp: `style="color: yahw-invalid;"`},
+		{Name: "Url", Node: Styles{"background": "URL(javascript:x)"}, Exp: `style="background: yahw-invalid;"`},
+		{Name: "Image set", Node: Styles{"background": "image-set(a 1x)"}, Exp: `style="background: yahw-invalid;"`},
+		{Name: "Expression", Node: Styles{"width": "expression(alert(1))"}, Exp: `style="width: yahw-invalid;"`},
+		{Name: "Escape", Node: Styles{"color": `\72 ed`}, Exp: `style="color: yahw-invalid;"`},
+		{Name: "Block", Node: Styles{"color": "red}"}, Exp: `style="color: yahw-invalid;"`},
+		{Name: "Comment", Node: Styles{"color": "red /*"}, Exp: `style="color: yahw-invalid;"`},
+		{Name: "Unclosed quote", Node: Styles{"content": `"a`}, Exp: `style="content: yahw-invalid;"`},
+		{Name: "Semicolon in quote", Node: Styles{"content": `"a;b"`}, Exp: `style="content: yahw-invalid;"`},
+		{Name: "Set", Node: Styles{"color": "red"}.Set("color", "blue").Set("margin", "0"), Exp: `style="color: blue; margin: 0;"`},
+		{Name: "Merged with style attribute", Node: Div(StyleAttr("color: red; padding: 0"), Styles{"color": "blue", "margin": "0"}), Exp: `<div style="color: blue; padding: 0; margin: 0;"></div>`},
+		{Name: "Merged with styles", Node: Input(Styles{"margin": "0"}, Styles{"color": "red"}), Exp: `<input style="margin: 0; color: red;" />`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Node, tc.Exp)
+		})
+	}
+
+	var nilStyles Styles
+	assertEqual(t, nilStyles.Set("color", "red"), `style="color: red;"`)
+}
+
+func TestInvalidStyleProperty(t *testing.T) {
+	for _, prop := range []string{"", "color:red", "a b", "1px", "behavior", "-moz-binding", "--"} {
+		for _, node := range []Renderable{Styles{prop: "x"}, Div(StyleAttr("color: red"), Styles{prop: "x"})} {
+			err := node.Render(context.Background(), &strings.Builder{})
+			if !errors.Is(err, ErrInvalidStyleProperty) {
+				t.Errorf("Expected ErrInvalidStyleProperty for %q, got %v", prop, err)
+			}
+		}
+	}
+}
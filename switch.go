@@ -0,0 +1,59 @@
+package yahw
+
+import (
+	"context"
+	"io"
+)
+
+type switchCase[T comparable] struct {
+	value T
+	node  Node
+}
+
+// SwitchNode renders the node of the first case matching its value, or the
+// default node if none does.
+type SwitchNode[T comparable] struct {
+	value T
+	cases []switchCase[T]
+	def   Node
+}
+
+func Switch[T comparable](value T) SwitchNode[T] {
+	return SwitchNode[T]{value: value}
+}
+
+func (s SwitchNode[T]) Case(value T, node Node) SwitchNode[T] {
+	// Copy the cases, so switches sharing a prefix don't overwrite each other.
+	cases := make([]switchCase[T], len(s.cases), len(s.cases)+1)
+	copy(cases, s.cases)
+	s.cases = append(cases, switchCase[T]{value: value, node: node})
+	return s
+}
+
+func (s SwitchNode[T]) Default(node Node) SwitchNode[T] {
+	s.def = node
+	return s
+}
+
+func (s SwitchNode[T]) attr() {}
+
+func (s SwitchNode[T]) Node(ctx context.Context) Renderable {
+	eval := s.evaluate(ctx)
+	if eval == nil {
+		return nil
+	}
+	return eval
+}
+
+func (s SwitchNode[T]) Render(ctx context.Context, w io.Writer) error {
+	return renderEvaluated(ctx, w, s.evaluate(ctx))
+}
+
+func (s SwitchNode[T]) evaluate(ctx context.Context) Renderable {
+	for _, c := range s.cases {
+		if c.value == s.value {
+			return resolve(ctx, c.node)
+		}
+	}
+	return resolve(ctx, s.def)
+}
@@ -0,0 +1,30 @@
+package yahw
+
+import "testing"
+
+func TestSwitch(t *testing.T) {
+	status := func(s string) Node {
+		return Switch(s).
+			Case("ok", Span(Classes("green"), Text("OK"))).
+			Case("error", Span(Classes("red"), Text("Error"))).
+			Default(Span(Text("Unknown")))
+	}
+
+	assertEqual(t, Div(status("ok")), `<div><span class="green">OK</span></div>`)
+	assertEqual(t, Div(status("error")), `<div><span class="red">Error</span></div>`)
+	assertEqual(t, Div(status("other")), `<div><span>Unknown</span></div>`)
+}
+
+func TestSwitchFirstMatchWins(t *testing.T) {
+	assertEqual(t, Div(Switch(1).Case(1, Text("a")).Case(1, Text("b"))), "<div>a</div>")
+	assertEqual(t, Div(Switch(2).Case(1, Text("a"))), "<div></div>")
+}
+
+func TestSwitchCasesAreNotShared(t *testing.T) {
+	base := Switch(2).Case(1, Text("one"))
+	a := base.Case(2, Text("a"))
+	b := base.Case(2, Text("b"))
+
+	assertEqual(t, Div(a), "<div>a</div>")
+	assertEqual(t, Div(b), "<div>b</div>")
+}
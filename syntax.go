@@ -0,0 +1,91 @@
+package yahw
+
+import (
+	"context"
+	"strings"
+)
+
+// Syntax selects how RenderTo serializes elements.
+type Syntax int
+
+const (
+	// PolyglotSyntax ends void elements and elements built with
+	// SelfClosingTagBuilder with " />", which HTML parsers accept for void
+	// elements and foreign content like SVG. It's the default.
+	PolyglotSyntax Syntax = iota
+	// HTML5Syntax writes void elements like <br> without the closing slash.
+	// Other elements are always closed with an end tag, even when built with
+	// SelfClosingTagBuilder.
+	HTML5Syntax
+	// XHTMLSyntax writes well-formed XML: elements without children are
+	// self-closed, boolean attributes are written as checked="checked" and
+	// script and style contents are escaped like any other text.
+	XHTMLSyntax
+)
+
+// UseSyntax sets how RenderTo serializes elements. Void elements never get
+// an end tag, whatever the syntax or builder.
+func UseSyntax(s Syntax) RenderOption {
+	return func(c *renderConfig) {
+		c.syntax = s
+	}
+}
+
+type syntaxKey struct{}
+
+func withSyntax(ctx context.Context, s Syntax) context.Context {
+	return context.WithValue(ctx, syntaxKey{}, s)
+}
+
+func syntaxOf(ctx context.Context) Syntax {
+	s, _ := ctx.Value(syntaxKey{}).(Syntax)
+	return s
+}
+
+// voidElements can't have any content and never have an end tag.
+var voidElements = map[string]bool{
+	"area":   true,
+	"base":   true,
+	"br":     true,
+	"col":    true,
+	"embed":  true,
+	"hr":     true,
+	"img":    true,
+	"input":  true,
+	"link":   true,
+	"meta":   true,
+	"param":  true,
+	"source": true,
+	"track":  true,
+	"wbr":    true,
+}
+
+func isVoidElement(tagName string) bool {
+	return voidElements[strings.ToLower(tagName)]
+}
+
+// voidEnd returns what ends the start tag of a void element.
+func voidEnd(ctx context.Context) string {
+	switch syntaxOf(ctx) {
+	case HTML5Syntax:
+		return ">"
+	case XHTMLSyntax:
+		return " />"
+	}
+	if minifiesMarkup(ctx) {
+		return ">"
+	}
+	return " />"
+}
+
+// emptyEnd returns what ends an element without content built with
+// SelfClosingTagBuilder.
+func emptyEnd(ctx context.Context, tagName string) string {
+	if isVoidElement(tagName) {
+		return voidEnd(ctx)
+	}
+	if syntaxOf(ctx) == HTML5Syntax || minifiesMarkup(ctx) {
+		return "></" + tagName + ">"
+	}
+	return " />"
+}
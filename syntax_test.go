@@ -0,0 +1,111 @@
+package yahw
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSyntax(t *testing.T) {
+	svg, svgPath := TagBuilder("svg"), SelfClosingTagBuilder("path")
+	tt := []struct {
+		Name     string
+		Node     Node
+		Polyglot string
+		HTML5    string
+		XHTML    string
+	}{
+		{
+			Name:     "Void element",
+			Node:     Br(),
+			Polyglot: "<br />",
+			HTML5:    "<br>",
+			XHTML:    "<br />",
+		},
+		{
+			Name:     "Void element built as common tag",
+			Node:     Div(NewTag("br"), TagBuilder("IMG")(Src("/a.png"))),
+			Polyglot: `<div><br /><IMG src="/a.png" /></div>`,
+			HTML5:    `<div><br><IMG src="/a.png"></div>`,
+			XHTML:    `<div><br /><IMG src="/a.png" /></div>`,
+		},
+		{
+			Name:     "Self-closing non-void element",
+			Node:     svg(svgPath(BuildAttr("d", "M0 0"))),
+			Polyglot: `<svg><path d="M0 0" /></svg>`,
+			HTML5:    `<svg><path d="M0 0"></path></svg>`,
+			XHTML:    `<svg><path d="M0 0" /></svg>`,
+		},
+		{
+			Name:     "Empty element",
+			Node:     Div(Span(), P(Text(""))),
+			Polyglot: "<div><span></span><p></p></div>",
+			HTML5:    "<div><span></span><p></p></div>",
+			XHTML:    "<div><span /><p></p></div>",
+		},
+		{
+			Name:     "Boolean attributes",
+			Node:     Input(Checked(), Disabled(), Value("")),
+			Polyglot: `<input checked disabled value="" />`,
+			HTML5:    `<input checked disabled value="">`,
+			XHTML:    `<input checked="checked" disabled="disabled" value="" />`,
+		},
+		{
+			Name:     "Script",
+			Node:     Script(Text("a < b && c")),
+			Polyglot: "<script>a < b && c</script>",
+			HTML5:    "<script>a < b && c</script>",
+			XHTML:    "<script>a &lt; b &amp;&amp; c</script>",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			for syntax, exp := range map[Syntax]string{PolyglotSyntax: tc.Polyglot, HTML5Syntax: tc.HTML5, XHTMLSyntax: tc.XHTML} {
+				strbuf := &strings.Builder{}
+				err := RenderTo(context.Background(), strbuf, tc.Node, UseSyntax(syntax))
+				if err != nil {
+					t.Fatalf("Error rendering: %s", err)
+				}
+				if strbuf.String() != exp {
+					t.Errorf("Syntax %d: expected %s, got %s", syntax, exp, strbuf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestSyntaxWithMinify(t *testing.T) {
+	node := Ul(Li(Input(Type("checkbox"), Checked())), Li(Text("a  b")))
+	tt := []struct {
+		Syntax Syntax
+		Exp    string
+	}{
+		{Syntax: HTML5Syntax, Exp: "<ul><li><input type=checkbox checked><li>a b</ul>"},
+		{Syntax: XHTMLSyntax, Exp: `<ul><li><input type="checkbox" checked="checked" /></li><li>a b</li></ul>`},
+	}
+
+	for _, tc := range tt {
+		strbuf := &strings.Builder{}
+		err := RenderTo(context.Background(), strbuf, node, UseSyntax(tc.Syntax), Minify())
+		if err != nil {
+			t.Fatalf("Error rendering: %s", err)
+		}
+		if strbuf.String() != tc.Exp {
+			t.Errorf("Syntax %d: expected %s, got %s", tc.Syntax, tc.Exp, strbuf.String())
+		}
+	}
+}
+
+func TestVoidElementWithChildren(t *testing.T) {
+	err := NewTag("br").Render(context.Background(), &strings.Builder{})
+	if err != nil {
+		t.Fatalf("Error rendering: %s", err)
+	}
+
+	err = TagBuilder("br")(Text("x")).Render(context.Background(), &strings.Builder{})
+	if !errors.Is(err, ErrInvalidNode) {
+		t.Errorf("Expected ErrInvalidNode, got %v", err)
+	}
+}
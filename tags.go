@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 )
 
 func isValidTagName(tagName string) bool {
@@ -29,6 +30,7 @@ func NewTag(tagName string) CommonTag {
 	}
 }
 
+// unwrapNodes resolves nodes, flattening fragments wherever they come from.
 func unwrapNodes(ctx context.Context, nodes []Node) []Renderable {
 	nn := []Renderable{}
 	for _, n := range nodes {
@@ -36,18 +38,32 @@ func unwrapNodes(ctx context.Context, nodes []Node) []Renderable {
 			continue
 		}
 		switch t := n.(type) {
-		case Nodes:
+		case Fragment:
 			nn = append(nn, unwrapNodes(ctx, t)...)
 		default:
-			nn = append(nn, n.Node(ctx))
+			r := n.Node(ctx)
+			if f, ok := r.(Fragment); ok {
+				nn = append(nn, unwrapNodes(ctx, f)...)
+				continue
+			}
+			nn = append(nn, r)
 		}
 	}
 	return nn
 }
 
 func TagBuilder(tagName string) func(...Node) CommonTag {
+	builder, err := TryTagBuilder(tagName)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// TryTagBuilder is like TagBuilder, but returns an error for an invalid tag name.
+func TryTagBuilder(tagName string) (func(...Node) CommonTag, error) {
 	if !isValidTagName(tagName) {
-		panic("Invalid tag name: " + tagName)
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTagName, tagName)
 	}
 
 	return func(nodes ...Node) CommonTag {
@@ -57,19 +73,29 @@ func TagBuilder(tagName string) func(...Node) CommonTag {
 		}
 
 		return ct
-	}
+	}, nil
 }
 
 func SelfClosingTagBuilder(tagName string) func(...attrable) SelfClosingTag {
+	builder, err := TrySelfClosingTagBuilder(tagName)
+	if err != nil {
+		panic(err)
+	}
+	return builder
+}
+
+// TrySelfClosingTagBuilder is like SelfClosingTagBuilder, but returns an error
+// for an invalid tag name.
+func TrySelfClosingTagBuilder(tagName string) (func(...attrable) SelfClosingTag, error) {
 	if !isValidTagName(tagName) {
-		panic("Invalid self closing tag name: " + tagName)
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTagName, tagName)
 	}
 	return func(attrs ...attrable) SelfClosingTag {
 		return SelfClosingTag{
 			tagName: tagName,
 			attrs:   attrs,
 		}
-	}
+	}, nil
 }
 
 type SelfClosingTag struct {
@@ -80,7 +106,7 @@ type SelfClosingTag struct {
 func (t SelfClosingTag) tag()                                {}
 func (t SelfClosingTag) Node(ctx context.Context) Renderable { return t }
 
-func mergeClasses(clss AttrSlice) Classes {
+func mergeClasses(clss AttrSlice) (Classes, error) {
 	merged := Classes("")
 	for _, c := range clss {
 		switch t := c.(type) {
@@ -91,62 +117,29 @@ func mergeClasses(clss AttrSlice) Classes {
 		case Attribute:
 			merged = merged.Add(t.value)
 		default:
-			panic("can only merge attributes with class")
+			return "", fmt.Errorf("can't merge %T with class attributes", c)
 		}
 	}
 
-	return merged
+	return merged, nil
 }
 
 func (t SelfClosingTag) Render(ctx context.Context, w io.Writer) error {
-	_, err := w.Write([]byte("<" + t.tagName))
-	if err != nil {
-		return err
-	}
-
-	if len(t.attrs) > 0 {
-		w.Write([]byte(" "))
+	if !isValidTagName(t.tagName) {
+		return newRenderError(t.tagName, fmt.Errorf("%w: %q", ErrInvalidTagName, t.tagName))
 	}
 
-	newAttrs := AttrSlice{}
-	toMerge := map[string]AttrSlice{}
-	for _, attr := range t.attrs {
-		switch t := attr.(type) {
-		case Classes, ClassesMap:
-			toMerge["class"] = append(toMerge["class"], t)
-		case Attribute:
-			if t.key == "class" {
-				toMerge["class"] = append(toMerge["class"], t)
-			} else {
-				newAttrs = append(newAttrs, t)
-			}
-		default:
-			newAttrs = append(newAttrs, t)
-		}
-	}
-
-	for k, attrs := range toMerge {
-		switch k {
-		case "class":
-			newAttrs = append(newAttrs, mergeClasses(attrs))
-		}
+	err := writeStrings(w, "<", t.tagName)
+	if err != nil {
+		return err
 	}
 
-	for idx, attr := range newAttrs {
-		if attr == nil {
-			continue
-		}
-		err = attr.Render(ctx, w)
-		if err != nil {
-			return err
-		}
-
-		if idx < len(t.attrs)-1 {
-			w.Write([]byte(" "))
-		}
+	err = renderAttrs(ctx, w, t.attrs)
+	if err != nil {
+		return newRenderError(t.tagName, err)
 	}
 
-	_, err = w.Write([]byte(" />"))
+	_, err = io.WriteString(w, emptyEnd(ctx, t.tagName))
 	if err != nil {
 		return err
 	}
@@ -154,10 +147,6 @@ func (t SelfClosingTag) Render(ctx context.Context, w io.Writer) error {
 	return nil
 }
 
-type Nodes []Node
-
-func (n Nodes) Node(ctx context.Context) Renderable { panic("Nodes is not a node") }
-
 type CommonTag struct {
 	tagName string
 
@@ -168,9 +157,13 @@ func (t CommonTag) tag()                                {}
 func (t CommonTag) Node(ctx context.Context) Renderable { return t }
 
 func (t CommonTag) Render(ctx context.Context, w io.Writer) error {
+	if !isValidTagName(t.tagName) {
+		return newRenderError(t.tagName, fmt.Errorf("%w: %q", ErrInvalidTagName, t.tagName))
+	}
+
 	unwrapped := unwrapNodes(ctx, t.children)
 	attrs := AttrSlice{}
-	tags := TagSlice{}
+	tags := []taggable{}
 	for _, n := range unwrapped {
 		if n == nil {
 			continue
@@ -182,72 +175,87 @@ func (t CommonTag) Render(ctx context.Context, w io.Writer) error {
 		case taggable:
 			tags = append(tags, child)
 		default:
-			panic(fmt.Sprintf("Invalid node type %T for tag %s", n, t.tagName))
+			return newRenderError(t.tagName, fmt.Errorf("%w: %T", ErrInvalidNode, n))
+		}
+	}
+	void := isVoidElement(t.tagName)
+	if void && len(tags) > 0 {
+		return newRenderError(t.tagName, fmt.Errorf("%w: %T in a void element", ErrInvalidNode, tags[0]))
+	}
+	childCtx := withParentTag(ctx, t.tagName)
+	for _, child := range tags {
+		if err := validateNode(childCtx, child); err != nil {
+			return newRenderError(t.tagName, err)
 		}
 	}
-	_, err := w.Write([]byte("<" + t.tagName))
+
+	err := writeStrings(w, "<", t.tagName)
 	if err != nil {
 		return err
 	}
 
-	if len(attrs) > 0 {
-		w.Write([]byte(" "))
+	err = renderAttrs(ctx, w, attrs)
+	if err != nil {
+		return newRenderError(t.tagName, err)
 	}
 
-	newAttrs := AttrSlice{}
-	toMerge := map[string]AttrSlice{}
-	for _, attr := range attrs {
-		switch t := attr.(type) {
-		case Classes, ClassesMap:
-			toMerge["class"] = append(toMerge["class"], t)
-		case Attribute:
-			if t.key == "class" {
-				toMerge["class"] = append(toMerge["class"], t)
-			} else {
-				newAttrs = append(newAttrs, t)
-			}
-		default:
-			newAttrs = append(newAttrs, t)
-		}
+	switch {
+	case void:
+		return writeStrings(w, voidEnd(ctx))
+	case len(tags) == 0 && syntaxOf(ctx) == XHTMLSyntax:
+		return writeStrings(w, " />")
 	}
 
-	for k, attrs := range toMerge {
-		switch k {
-		case "class":
-			newAttrs = append(newAttrs, mergeClasses(attrs))
-		}
+	_, err = io.WriteString(w, ">")
+	if err != nil {
+		return err
 	}
-
-	for idx, attr := range newAttrs {
-		if attr == nil {
-			continue
-		}
-		err = attr.Render(ctx, w)
-		if err != nil {
+	// HTML parsers drop a newline right after these start tags, so content
+	// starting with one needs another.
+	if syntaxOf(ctx) != XHTMLSyntax && dropsLeadingNewline(t.tagName) && startsWithNewline(ctx, tags) {
+		if err := writeStrings(w, "\n"); err != nil {
 			return err
 		}
-
-		if idx < len(attrs)-1 {
-			w.Write([]byte(" "))
-		}
 	}
 
-	_, err = w.Write([]byte(">"))
-	if err != nil {
-		return err
+	l, indented := layoutOf(ctx)
+	breaks := false
+	if indented {
+		inner := l.child(t.tagName)
+		breaks = breaksBetween(inner, tags)
+		ctx = withLayout(ctx, inner)
 	}
-
-	for _, child := range tags {
+	m, minified := minifyOf(ctx)
+	inner := m.child(t.tagName)
+	ctx = withParentTag(ctx, t.tagName)
+	for idx, child := range tags {
 		if child == nil {
 			continue
 		}
-		err = child.Render(ctx, w)
+		if breaks {
+			if err := l.newline(w, l.depth+1); err != nil {
+				return err
+			}
+		}
+		childCtx := ctx
+		if minified {
+			childCtx = withMinify(ctx, minifyStateAt(inner, tags, idx))
+		}
+		err = child.Render(childCtx, w)
 		if err != nil {
+			return wrapChildError(t.tagName, tags, idx, err)
+		}
+	}
+	if breaks {
+		if err := l.newline(w, l.depth); err != nil {
 			return err
 		}
 	}
+	if minified && minifiesMarkup(ctx) && omitsEndTag(t.tagName, m) {
+		return nil
+	}
 
-	_, err = w.Write([]byte("</" + t.tagName + ">"))
+	err = writeStrings(w, "</", t.tagName, ">")
 	if err != nil {
 		return err
 	}
@@ -255,6 +263,36 @@ func (t CommonTag) Render(ctx context.Context, w io.Writer) error {
 	return nil
 }
 
+func dropsLeadingNewline(tagName string) bool {
+	switch strings.ToLower(tagName) {
+	case "pre", "textarea", "listing":
+		return true
+	}
+	return false
+}
+
+// startsWithNewline reports whether the first of children is text starting
+// with a newline.
+func startsWithNewline[T Renderable](ctx context.Context, children []T) bool {
+	for _, child := range children {
+		switch c := Renderable(child).(type) {
+		case nil:
+			continue
+		case Text:
+			if c == "" {
+				continue
+			}
+			return c[0] == '\n'
+		case Fragment:
+			return startsWithNewline(ctx, unwrapNodes(ctx, c))
+		case TagSlice:
+			return startsWithNewline(ctx, unwrapNodes(ctx, c))
+		}
+		return false
+	}
+	return false
+}
+
 type HTML5Doctype struct {
 	children TagSlice
 }
@@ -263,140 +301,138 @@ func (t HTML5Doctype) tag()                                {}
 func (t HTML5Doctype) Node(ctx context.Context) Renderable { return t }
 
 func (t HTML5Doctype) Render(ctx context.Context, w io.Writer) error {
-	_, err := w.Write([]byte("<!DOCTYPE html>"))
+	_, err := io.WriteString(w, "<!DOCTYPE html>")
 	if err != nil {
 		return err
 	}
+	if l, ok := layoutOf(ctx); ok && len(t.children) > 0 {
+		if err := l.newline(w, l.depth); err != nil {
+			return err
+		}
+	}
 	return t.children.Render(ctx, w)
 }
 
-type TagSlice []taggable
+// TagSlice renders its nodes one after another. Components are resolved with
+// their Node method at render time, like within any other tag.
+type TagSlice []Node
 
 func (t TagSlice) tag() {}
 func (t TagSlice) Render(ctx context.Context, w io.Writer) error {
-	for _, tag := range t {
-		if tag == nil {
-			continue
-		}
-		err := tag.Render(ctx, w)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	return Fragment(t).Render(ctx, w)
 }
 func (t TagSlice) Node(ctx context.Context) Renderable { return t }
 
 // All known HTML5 tags
 
-func NewHTML5Doctype(cs ...taggable) HTML5Doctype { return HTML5Doctype{children: cs} }
-func A(attrs ...Node) CommonTag                   { return TagBuilder("a")(attrs...) }
-func Abbr(attrs ...Node) CommonTag                { return TagBuilder("abbr")(attrs...) }
-func Address(attrs ...Node) CommonTag             { return TagBuilder("address")(attrs...) }
-func Area(attrs ...attrable) SelfClosingTag       { return SelfClosingTagBuilder("area")(attrs...) }
-func Article(attrs ...Node) CommonTag             { return TagBuilder("article")(attrs...) }
-func Aside(attrs ...Node) CommonTag               { return TagBuilder("aside")(attrs...) }
-func Audio(attrs ...Node) CommonTag               { return TagBuilder("audio")(attrs...) }
-func B(attrs ...Node) CommonTag                   { return TagBuilder("b")(attrs...) }
-func Base(attrs ...attrable) SelfClosingTag       { return SelfClosingTagBuilder("base")(attrs...) }
-func Bdi(attrs ...Node) CommonTag                 { return TagBuilder("bdi")(attrs...) }
-func Bdo(attrs ...Node) CommonTag                 { return TagBuilder("bdo")(attrs...) }
-func Blockquote(attrs ...Node) CommonTag          { return TagBuilder("blockquote")(attrs...) }
-func Body(attrs ...Node) CommonTag                { return TagBuilder("body")(attrs...) }
-func Br(attrs ...attrable) SelfClosingTag         { return SelfClosingTagBuilder("br")(attrs...) }
-func Button(attrs ...Node) CommonTag              { return TagBuilder("button")(attrs...) }
-func Canvas(attrs ...Node) CommonTag              { return TagBuilder("canvas")(attrs...) }
-func Caption(attrs ...Node) CommonTag             { return TagBuilder("caption")(attrs...) }
-func Cite(attrs ...Node) CommonTag                { return TagBuilder("cite")(attrs...) }
-func Code(attrs ...Node) CommonTag                { return TagBuilder("code")(attrs...) }
-func Col(attrs ...attrable) SelfClosingTag        { return SelfClosingTagBuilder("col")(attrs...) }
-func Colgroup(attrs ...Node) CommonTag            { return TagBuilder("colgroup")(attrs...) }
-func Data(attrs ...Node) CommonTag                { return TagBuilder("data")(attrs...) }
-func Datalist(attrs ...Node) CommonTag            { return TagBuilder("datalist")(attrs...) }
-func Dd(attrs ...Node) CommonTag                  { return TagBuilder("dd")(attrs...) }
-func Del(attrs ...Node) CommonTag                 { return TagBuilder("del")(attrs...) }
-func Details(attrs ...Node) CommonTag             { return TagBuilder("details")(attrs...) }
-func Dfn(attrs ...Node) CommonTag                 { return TagBuilder("dfn")(attrs...) }
-func Dialog(attrs ...Node) CommonTag              { return TagBuilder("dialog")(attrs...) }
-func Div(attrs ...Node) CommonTag                 { return TagBuilder("div")(attrs...) }
-func Dl(attrs ...Node) CommonTag                  { return TagBuilder("dl")(attrs...) }
-func Dt(attrs ...Node) CommonTag                  { return TagBuilder("dt")(attrs...) }
-func Em(attrs ...Node) CommonTag                  { return TagBuilder("em")(attrs...) }
-func Embed(attrs ...attrable) SelfClosingTag      { return SelfClosingTagBuilder("embed")(attrs...) }
-func Fieldset(attrs ...Node) CommonTag            { return TagBuilder("fieldset")(attrs...) }
-func Figcaption(attrs ...Node) CommonTag          { return TagBuilder("figcaption")(attrs...) }
-func Figure(attrs ...Node) CommonTag              { return TagBuilder("figure")(attrs...) }
-func Footer(attrs ...Node) CommonTag              { return TagBuilder("footer")(attrs...) }
-func Form(attrs ...Node) CommonTag                { return TagBuilder("form")(attrs...) }
-func H1(attrs ...Node) CommonTag                  { return TagBuilder("h1")(attrs...) }
-func H2(attrs ...Node) CommonTag                  { return TagBuilder("h2")(attrs...) }
-func H3(attrs ...Node) CommonTag                  { return TagBuilder("h3")(attrs...) }
-func H4(attrs ...Node) CommonTag                  { return TagBuilder("h4")(attrs...) }
-func H5(attrs ...Node) CommonTag                  { return TagBuilder("h5")(attrs...) }
-func H6(attrs ...Node) CommonTag                  { return TagBuilder("h6")(attrs...) }
-func Head(attrs ...Node) CommonTag                { return TagBuilder("head")(attrs...) }
-func Header(attrs ...Node) CommonTag              { return TagBuilder("header")(attrs...) }
-func Hr(attrs ...attrable) SelfClosingTag         { return SelfClosingTagBuilder("hr")(attrs...) }
-func HTML(attrs ...Node) CommonTag                { return TagBuilder("html")(attrs...) }
-func I(attrs ...Node) CommonTag                   { return TagBuilder("i")(attrs...) }
-func Iframe(attrs ...Node) CommonTag              { return TagBuilder("iframe")(attrs...) }
-func Img(attrs ...attrable) SelfClosingTag        { return SelfClosingTagBuilder("img")(attrs...) }
-func Input(attrs ...attrable) SelfClosingTag      { return SelfClosingTagBuilder("input")(attrs...) }
-func Ins(attrs ...Node) CommonTag                 { return TagBuilder("ins")(attrs...) }
-func Kbd(attrs ...Node) CommonTag                 { return TagBuilder("kbd")(attrs...) }
-func Label(attrs ...Node) CommonTag               { return TagBuilder("label")(attrs...) }
-func Legend(attrs ...Node) CommonTag              { return TagBuilder("legend")(attrs...) }
-func Li(attrs ...Node) CommonTag                  { return TagBuilder("li")(attrs...) }
-func Link(attrs ...attrable) SelfClosingTag       { return SelfClosingTagBuilder("link")(attrs...) }
-func Main(attrs ...Node) CommonTag                { return TagBuilder("main")(attrs...) }
-func Map(attrs ...Node) CommonTag                 { return TagBuilder("map")(attrs...) }
-func Mark(attrs ...Node) CommonTag                { return TagBuilder("mark")(attrs...) }
-func Meta(attrs ...attrable) SelfClosingTag       { return SelfClosingTagBuilder("meta")(attrs...) }
-func Meter(attrs ...Node) CommonTag               { return TagBuilder("meter")(attrs...) }
-func Nav(attrs ...Node) CommonTag                 { return TagBuilder("nav")(attrs...) }
-func Noscript(attrs ...Node) CommonTag            { return TagBuilder("noscript")(attrs...) }
-func Object(attrs ...Node) CommonTag              { return TagBuilder("object")(attrs...) }
-func Ol(attrs ...Node) CommonTag                  { return TagBuilder("ol")(attrs...) }
-func Optgroup(attrs ...Node) CommonTag            { return TagBuilder("optgroup")(attrs...) }
-func Option(attrs ...Node) CommonTag              { return TagBuilder("option")(attrs...) }
-func Output(attrs ...Node) CommonTag              { return TagBuilder("output")(attrs...) }
-func P(attrs ...Node) CommonTag                   { return TagBuilder("p")(attrs...) }
-func Param(attrs ...attrable) SelfClosingTag      { return SelfClosingTagBuilder("param")(attrs...) }
-func Picture(attrs ...Node) CommonTag             { return TagBuilder("picture")(attrs...) }
-func Pre(attrs ...Node) CommonTag                 { return TagBuilder("pre")(attrs...) }
-func Progress(attrs ...Node) CommonTag            { return TagBuilder("progress")(attrs...) }
-func Q(attrs ...Node) CommonTag                   { return TagBuilder("q")(attrs...) }
-func Rp(attrs ...Node) CommonTag                  { return TagBuilder("rp")(attrs...) }
-func Rt(attrs ...Node) CommonTag                  { return TagBuilder("rt")(attrs...) }
-func Ruby(attrs ...Node) CommonTag                { return TagBuilder("ruby")(attrs...) }
-func S(attrs ...Node) CommonTag                   { return TagBuilder("s")(attrs...) }
-func Samp(attrs ...Node) CommonTag                { return TagBuilder("samp")(attrs...) }
-func Script(attrs ...Node) CommonTag              { return TagBuilder("script")(attrs...) }
-func Section(attrs ...Node) CommonTag             { return TagBuilder("section")(attrs...) }
-func Select(attrs ...Node) CommonTag              { return TagBuilder("select")(attrs...) }
-func Slot(attrs ...Node) CommonTag                { return TagBuilder("slot")(attrs...) }
-func Small(attrs ...Node) CommonTag               { return TagBuilder("small")(attrs...) }
-func Source(attrs ...attrable) SelfClosingTag     { return SelfClosingTagBuilder("source")(attrs...) }
-func Span(attrs ...Node) CommonTag                { return TagBuilder("span")(attrs...) }
-func Strong(attrs ...Node) CommonTag              { return TagBuilder("strong")(attrs...) }
-func Style(attrs ...Node) CommonTag               { return TagBuilder("style")(attrs...) }
-func Sub(attrs ...Node) CommonTag                 { return TagBuilder("sub")(attrs...) }
-func Summary(attrs ...Node) CommonTag             { return TagBuilder("summary")(attrs...) }
-func Sup(attrs ...Node) CommonTag                 { return TagBuilder("sup")(attrs...) }
-func Table(attrs ...Node) CommonTag               { return TagBuilder("table")(attrs...) }
-func Tbody(attrs ...Node) CommonTag               { return TagBuilder("tbody")(attrs...) }
-func Td(attrs ...Node) CommonTag                  { return TagBuilder("td")(attrs...) }
-func Template(attrs ...Node) CommonTag            { return TagBuilder("template")(attrs...) }
-func Textarea(attrs ...Node) CommonTag            { return TagBuilder("textarea")(attrs...) }
-func Tfoot(attrs ...Node) CommonTag               { return TagBuilder("tfoot")(attrs...) }
-func Th(attrs ...Node) CommonTag                  { return TagBuilder("th")(attrs...) }
-func Thead(attrs ...Node) CommonTag               { return TagBuilder("thead")(attrs...) }
-func Time(attrs ...Node) CommonTag                { return TagBuilder("time")(attrs...) }
-func Title(attrs ...Node) CommonTag               { return TagBuilder("title")(attrs...) }
-func Tr(attrs ...Node) CommonTag                  { return TagBuilder("tr")(attrs...) }
-func Track(attrs ...attrable) SelfClosingTag      { return SelfClosingTagBuilder("track")(attrs...) }
-func U(attrs ...Node) CommonTag                   { return TagBuilder("u")(attrs...) }
-func Ul(attrs ...Node) CommonTag                  { return TagBuilder("ul")(attrs...) }
-func Var(attrs ...Node) CommonTag                 { return TagBuilder("var")(attrs...) }
-func Video(attrs ...Node) CommonTag               { return TagBuilder("video")(attrs...) }
-func Wbr(attrs ...attrable) SelfClosingTag        { return SelfClosingTagBuilder("wbr")(attrs...) }
+func NewHTML5Doctype(cs ...Node) HTML5Doctype { return HTML5Doctype{children: cs} }
+func A(attrs ...Node) CommonTag               { return TagBuilder("a")(attrs...) }
+func Abbr(attrs ...Node) CommonTag            { return TagBuilder("abbr")(attrs...) }
+func Address(attrs ...Node) CommonTag         { return TagBuilder("address")(attrs...) }
+func Area(attrs ...attrable) SelfClosingTag   { return SelfClosingTagBuilder("area")(attrs...) }
+func Article(attrs ...Node) CommonTag         { return TagBuilder("article")(attrs...) }
+func Aside(attrs ...Node) CommonTag           { return TagBuilder("aside")(attrs...) }
+func Audio(attrs ...Node) CommonTag           { return TagBuilder("audio")(attrs...) }
+func B(attrs ...Node) CommonTag               { return TagBuilder("b")(attrs...) }
+func Base(attrs ...attrable) SelfClosingTag   { return SelfClosingTagBuilder("base")(attrs...) }
+func Bdi(attrs ...Node) CommonTag             { return TagBuilder("bdi")(attrs...) }
+func Bdo(attrs ...Node) CommonTag             { return TagBuilder("bdo")(attrs...) }
+func Blockquote(attrs ...Node) CommonTag      { return TagBuilder("blockquote")(attrs...) }
+func Body(attrs ...Node) CommonTag            { return TagBuilder("body")(attrs...) }
+func Br(attrs ...attrable) SelfClosingTag     { return SelfClosingTagBuilder("br")(attrs...) }
+func Button(attrs ...Node) CommonTag          { return TagBuilder("button")(attrs...) }
+func Canvas(attrs ...Node) CommonTag          { return TagBuilder("canvas")(attrs...) }
+func Caption(attrs ...Node) CommonTag         { return TagBuilder("caption")(attrs...) }
+func Cite(attrs ...Node) CommonTag            { return TagBuilder("cite")(attrs...) }
+func Code(attrs ...Node) CommonTag            { return TagBuilder("code")(attrs...) }
+func Col(attrs ...attrable) SelfClosingTag    { return SelfClosingTagBuilder("col")(attrs...) }
+func Colgroup(attrs ...Node) CommonTag        { return TagBuilder("colgroup")(attrs...) }
+func Data(attrs ...Node) CommonTag            { return TagBuilder("data")(attrs...) }
+func Datalist(attrs ...Node) CommonTag        { return TagBuilder("datalist")(attrs...) }
+func Dd(attrs ...Node) CommonTag              { return TagBuilder("dd")(attrs...) }
+func Del(attrs ...Node) CommonTag             { return TagBuilder("del")(attrs...) }
+func Details(attrs ...Node) CommonTag         { return TagBuilder("details")(attrs...) }
+func Dfn(attrs ...Node) CommonTag             { return TagBuilder("dfn")(attrs...) }
+func Dialog(attrs ...Node) CommonTag          { return TagBuilder("dialog")(attrs...) }
+func Div(attrs ...Node) CommonTag             { return TagBuilder("div")(attrs...) }
+func Dl(attrs ...Node) CommonTag              { return TagBuilder("dl")(attrs...) }
+func Dt(attrs ...Node) CommonTag              { return TagBuilder("dt")(attrs...) }
+func Em(attrs ...Node) CommonTag              { return TagBuilder("em")(attrs...) }
+func Embed(attrs ...attrable) SelfClosingTag  { return SelfClosingTagBuilder("embed")(attrs...) }
+func Fieldset(attrs ...Node) CommonTag        { return TagBuilder("fieldset")(attrs...) }
+func Figcaption(attrs ...Node) CommonTag      { return TagBuilder("figcaption")(attrs...) }
+func Figure(attrs ...Node) CommonTag          { return TagBuilder("figure")(attrs...) }
+func Footer(attrs ...Node) CommonTag          { return TagBuilder("footer")(attrs...) }
+func Form(attrs ...Node) CommonTag            { return TagBuilder("form")(attrs...) }
+func H1(attrs ...Node) CommonTag              { return TagBuilder("h1")(attrs...) }
+func H2(attrs ...Node) CommonTag              { return TagBuilder("h2")(attrs...) }
+func H3(attrs ...Node) CommonTag              { return TagBuilder("h3")(attrs...) }
+func H4(attrs ...Node) CommonTag              { return TagBuilder("h4")(attrs...) }
+func H5(attrs ...Node) CommonTag              { return TagBuilder("h5")(attrs...) }
+func H6(attrs ...Node) CommonTag              { return TagBuilder("h6")(attrs...) }
+func Head(attrs ...Node) CommonTag            { return TagBuilder("head")(attrs...) }
+func Header(attrs ...Node) CommonTag          { return TagBuilder("header")(attrs...) }
+func Hr(attrs ...attrable) SelfClosingTag     { return SelfClosingTagBuilder("hr")(attrs...) }
+func HTML(attrs ...Node) CommonTag            { return TagBuilder("html")(attrs...) }
+func I(attrs ...Node) CommonTag               { return TagBuilder("i")(attrs...) }
+func Iframe(attrs ...Node) CommonTag          { return TagBuilder("iframe")(attrs...) }
+func Img(attrs ...attrable) SelfClosingTag    { return SelfClosingTagBuilder("img")(attrs...) }
+func Input(attrs ...attrable) SelfClosingTag  { return SelfClosingTagBuilder("input")(attrs...) }
+func Ins(attrs ...Node) CommonTag             { return TagBuilder("ins")(attrs...) }
+func Kbd(attrs ...Node) CommonTag             { return TagBuilder("kbd")(attrs...) }
+func Label(attrs ...Node) CommonTag           { return TagBuilder("label")(attrs...) }
+func Legend(attrs ...Node) CommonTag          { return TagBuilder("legend")(attrs...) }
+func Li(attrs ...Node) CommonTag              { return TagBuilder("li")(attrs...) }
+func Link(attrs ...attrable) SelfClosingTag   { return SelfClosingTagBuilder("link")(attrs...) }
+func Main(attrs ...Node) CommonTag            { return TagBuilder("main")(attrs...) }
+func Map(attrs ...Node) CommonTag             { return TagBuilder("map")(attrs...) }
+func Mark(attrs ...Node) CommonTag            { return TagBuilder("mark")(attrs...) }
+func Meta(attrs ...attrable) SelfClosingTag   { return SelfClosingTagBuilder("meta")(attrs...) }
+func Meter(attrs ...Node) CommonTag           { return TagBuilder("meter")(attrs...) }
+func Nav(attrs ...Node) CommonTag             { return TagBuilder("nav")(attrs...) }
+func Noscript(attrs ...Node) CommonTag        { return TagBuilder("noscript")(attrs...) }
+func Object(attrs ...Node) CommonTag          { return TagBuilder("object")(attrs...) }
+func Ol(attrs ...Node) CommonTag              { return TagBuilder("ol")(attrs...) }
+func Optgroup(attrs ...Node) CommonTag        { return TagBuilder("optgroup")(attrs...) }
+func Option(attrs ...Node) CommonTag          { return TagBuilder("option")(attrs...) }
+func Output(attrs ...Node) CommonTag          { return TagBuilder("output")(attrs...) }
+func P(attrs ...Node) CommonTag               { return TagBuilder("p")(attrs...) }
+func Param(attrs ...attrable) SelfClosingTag  { return SelfClosingTagBuilder("param")(attrs...) }
+func Picture(attrs ...Node) CommonTag         { return TagBuilder("picture")(attrs...) }
+func Pre(attrs ...Node) CommonTag             { return TagBuilder("pre")(attrs...) }
+func Progress(attrs ...Node) CommonTag        { return TagBuilder("progress")(attrs...) }
+func Q(attrs ...Node) CommonTag               { return TagBuilder("q")(attrs...) }
+func Rp(attrs ...Node) CommonTag              { return TagBuilder("rp")(attrs...) }
+func Rt(attrs ...Node) CommonTag              { return TagBuilder("rt")(attrs...) }
+func Ruby(attrs ...Node) CommonTag            { return TagBuilder("ruby")(attrs...) }
+func S(attrs ...Node) CommonTag               { return TagBuilder("s")(attrs...) }
+func Samp(attrs ...Node) CommonTag            { return TagBuilder("samp")(attrs...) }
+func Script(attrs ...Node) CommonTag          { return TagBuilder("script")(attrs...) }
+func Section(attrs ...Node) CommonTag         { return TagBuilder("section")(attrs...) }
+func Select(attrs ...Node) CommonTag          { return TagBuilder("select")(attrs...) }
+func Slot(attrs ...Node) CommonTag            { return TagBuilder("slot")(attrs...) }
+func Small(attrs ...Node) CommonTag           { return TagBuilder("small")(attrs...) }
+func Source(attrs ...attrable) SelfClosingTag { return SelfClosingTagBuilder("source")(attrs...) }
+func Span(attrs ...Node) CommonTag            { return TagBuilder("span")(attrs...) }
+func Strong(attrs ...Node) CommonTag          { return TagBuilder("strong")(attrs...) }
+func Style(attrs ...Node) CommonTag           { return TagBuilder("style")(attrs...) }
+func Sub(attrs ...Node) CommonTag             { return TagBuilder("sub")(attrs...) }
+func Summary(attrs ...Node) CommonTag         { return TagBuilder("summary")(attrs...) }
+func Sup(attrs ...Node) CommonTag             { return TagBuilder("sup")(attrs...) }
+func Table(attrs ...Node) CommonTag           { return TagBuilder("table")(attrs...) }
+func Tbody(attrs ...Node) CommonTag           { return TagBuilder("tbody")(attrs...) }
+func Td(attrs ...Node) CommonTag              { return TagBuilder("td")(attrs...) }
+func Template(attrs ...Node) CommonTag        { return TagBuilder("template")(attrs...) }
+func Textarea(attrs ...Node) CommonTag        { return TagBuilder("textarea")(attrs...) }
+func Tfoot(attrs ...Node) CommonTag           { return TagBuilder("tfoot")(attrs...) }
+func Th(attrs ...Node) CommonTag              { return TagBuilder("th")(attrs...) }
+func Thead(attrs ...Node) CommonTag           { return TagBuilder("thead")(attrs...) }
+func Time(attrs ...Node) CommonTag            { return TagBuilder("time")(attrs...) }
+func Title(attrs ...Node) CommonTag           { return TagBuilder("title")(attrs...) }
+func Tr(attrs ...Node) CommonTag              { return TagBuilder("tr")(attrs...) }
+func Track(attrs ...attrable) SelfClosingTag  { return SelfClosingTagBuilder("track")(attrs...) }
+func U(attrs ...Node) CommonTag               { return TagBuilder("u")(attrs...) }
+func Ul(attrs ...Node) CommonTag              { return TagBuilder("ul")(attrs...) }
+func Var(attrs ...Node) CommonTag             { return TagBuilder("var")(attrs...) }
+func Video(attrs ...Node) CommonTag           { return TagBuilder("video")(attrs...) }
+func Wbr(attrs ...attrable) SelfClosingTag    { return SelfClosingTagBuilder("wbr")(attrs...) }
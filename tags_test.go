@@ -1,7 +1,11 @@
 package yahw
 
 import (
+	"context"
+	"strings"
 	"testing"
+
+	"golang.org/x/net/html"
 )
 
 func TestCreatingTags(t *testing.T) {
@@ -66,3 +70,60 @@ func TestCreatingInvalidTags(t *testing.T) {
 		})
 	}
 }
+
+func TestLeadingNewlineIsKept(t *testing.T) {
+	tt := []struct {
+		Name string
+		Node Node
+		Text string
+		Exp  string
+	}{
+		{Name: "Pre", Node: Pre(Text("\nfoo")), Text: "\nfoo", Exp: "<pre>\n\nfoo</pre>"},
+		{Name: "Textarea", Node: Textarea(Text("\n x ")), Text: "\n x ", Exp: "<textarea>\n\n x </textarea>"},
+		{Name: "Listing", Node: TagBuilder("listing")(Text("\n\na")), Text: "\n\na", Exp: "<listing>\n\n\na</listing>"},
+		{Name: "Within a fragment", Node: Pre(Fragment{Text(""), Text("\na")}), Text: "\na", Exp: "<pre>\n\na</pre>"},
+		{Name: "No newline", Node: Pre(Text("a\n")), Text: "a\n", Exp: "<pre>a\n</pre>"},
+		{Name: "Element first", Node: Pre(B(Text("\na"))), Text: "\na", Exp: "<pre><b>\na</b></pre>"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			for _, opts := range [][]RenderOption{nil, {Minify()}, {Indent("  ")}} {
+				strbuf := &strings.Builder{}
+				if err := RenderTo(context.Background(), strbuf, Span(tc.Node), opts...); err != nil {
+					t.Fatalf("Error rendering: %s", err)
+				}
+				if exp := "<span>" + tc.Exp + "</span>"; strbuf.String() != exp {
+					t.Errorf("Expected %q, got %q", exp, strbuf.String())
+				}
+
+				doc, err := html.Parse(strings.NewReader(strbuf.String()))
+				if err != nil {
+					t.Fatalf("Error parsing: %s", err)
+				}
+				text := &strings.Builder{}
+				var collect func(n *html.Node)
+				collect = func(n *html.Node) {
+					if n.Type == html.TextNode {
+						text.WriteString(n.Data)
+					}
+					for c := n.FirstChild; c != nil; c = c.NextSibling {
+						collect(c)
+					}
+				}
+				collect(doc)
+				if text.String() != tc.Text {
+					t.Errorf("Expected the text %q to be parsed back, got %q", tc.Text, text.String())
+				}
+			}
+		})
+	}
+
+	strbuf := &strings.Builder{}
+	if err := RenderTo(context.Background(), strbuf, Pre(Text("\nfoo")), UseSyntax(XHTMLSyntax)); err != nil {
+		t.Fatalf("Error rendering: %s", err)
+	}
+	if strbuf.String() != "<pre>\nfoo</pre>" {
+		t.Errorf("Expected no extra newline in XHTML, got %q", strbuf.String())
+	}
+}
@@ -5,12 +5,18 @@ import (
 	"io"
 )
 
+// Text is escaped according to the element it's rendered in. Use Raw to
+// write markup verbatim.
 type Text string
 
 func (t Text) tag()                                {}
 func (t Text) Node(ctx context.Context) Renderable { return t }
 
 func (t Text) Render(ctx context.Context, w io.Writer) error {
-	_, err := w.Write([]byte(t))
+	s := string(t)
+	if m, ok := minifyOf(ctx); ok && !m.preformatted {
+		s = collapseSpace(s)
+	}
+	_, err := io.WriteString(w, escapeText(ctx, s))
 	return err
 }
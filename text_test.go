@@ -14,3 +14,32 @@ func TestTextRenderingWithinTags(t *testing.T) {
 	assertEqual(t, T1(Text("foo")), "<T1>foo</T1>")
 	assertEqual(t, T1(Text("foo\nbar")), "<T1>foo\nbar</T1>")
 }
+
+func TestTextEscaping(t *testing.T) {
+	tt := []struct {
+		Name string
+		Node Renderable
+		Exp  string
+	}{
+		{Name: "Markup", Node: Text("<b>bold</b>"), Exp: "&lt;b&gt;bold&lt;/b&gt;"},
+		{Name: "Ampersand", Node: Text("fish & chips"), Exp: "fish &amp; chips"},
+		{Name: "Quotes", Node: Text(`"it's"`), Exp: "&#34;it&#39;s&#34;"},
+		{Name: "Within element", Node: P(Text("<script>")), Exp: "<p>&lt;script&gt;</p>"},
+		{Name: "Title", Node: Title(Text("a < b & c")), Exp: "<title>a &lt; b &amp; c</title>"},
+		{Name: "Textarea", Node: Textarea(Text("</textarea>")), Exp: "<textarea>&lt;/textarea&gt;</textarea>"},
+		{Name: "Script", Node: Script(Text("if (a < b && c > d) {}")), Exp: "<script>if (a < b && c > d) {}</script>"},
+		{Name: "Script closing tag", Node: Script(Text(`x = "</script><b>"`)), Exp: `<script>x = "<\/script><b>"</script>`},
+		{Name: "Script closing tag uppercase", Node: Script(Text(`x = "</SCRIPT>"`)), Exp: `<script>x = "<\/SCRIPT>"</script>`},
+		{Name: "Script comment", Node: Script(Text(`x = "<!--"`)), Exp: `<script>x = "<\!--"</script>`},
+		{Name: "Style", Node: Style(Text(`a > b { content: "&"; }`)), Exp: `<style>a > b { content: "&"; }</style>`},
+		{Name: "Style closing tag", Node: Style(Text(`</style><script>`)), Exp: `<style><\/style><script></style>`},
+		{Name: "Nested element resets", Node: Script(Span(Text("<"))), Exp: "<script><span>&lt;</span></script>"},
+		{Name: "Raw is not escaped", Node: P(Raw("<b>bold</b>")), Exp: "<p><b>bold</b></p>"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Node, tc.Exp)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package yahw
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// AttrOf builds an attribute from a value of any type. Numbers are written in
+// decimal without exponents, times in RFC 3339 and anything else with its
+// String method or, if it has none, like fmt.Sprint. It panics on an invalid
+// name, like BuildAttr.
+func AttrOf[T any](key string, value T) Attribute {
+	return BuildAttr(key, formatAttrValue(value))
+}
+
+func formatAttrValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(rv.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(rv.Float(), 'f', -1, 64)
+	case reflect.Bool:
+		return strconv.FormatBool(rv.Bool())
+	case reflect.String:
+		return rv.String()
+	}
+	return fmt.Sprint(value)
+}
+
+// BoolAttribute is a boolean attribute that's left out when it isn't set.
+type BoolAttribute struct {
+	key string
+	set bool
+}
+
+var (
+	_ Node     = BoolAttribute{}
+	_ attrable = BoolAttribute{}
+)
+
+// BoolAttr builds a boolean attribute that's only rendered if set is true,
+// e.g. BoolAttr("disabled", !editable). It panics on an invalid name, like
+// NoValAttr.
+func BoolAttr(key string, set bool) BoolAttribute {
+	return BoolAttribute{key: NoValAttr(key).key, set: set}
+}
+
+func (a BoolAttribute) attr()                               {}
+func (a BoolAttribute) Node(ctx context.Context) Renderable { return a }
+
+func (a BoolAttribute) Render(ctx context.Context, w io.Writer) error {
+	return renderEvaluated(ctx, w, a.evaluate(ctx))
+}
+
+func (a BoolAttribute) evaluate(ctx context.Context) Renderable {
+	if !a.set {
+		return nil
+	}
+	return NoValAttribute{key: a.key}
+}
+
+// Typed variants of the attribute helpers taking strings
+
+func TabIndexN(tabIndex int) Attribute   { return AttrOf("tabindex", tabIndex) }
+func ColSpanN(colSpan int) Attribute     { return AttrOf("colspan", colSpan) }
+func RowSpanN(rowSpan int) Attribute     { return AttrOf("rowspan", rowSpan) }
+func SizeN(size int) Attribute           { return AttrOf("size", size) }
+func WidthN(width int) Attribute         { return AttrOf("width", width) }
+func HeightN(height int) Attribute       { return AttrOf("height", height) }
+func MaxLengthN(maxLength int) Attribute { return AttrOf("maxlength", maxLength) }
+func MinLengthN(minLength int) Attribute { return AttrOf("minlength", minLength) }
+func MaxN(max float64) Attribute         { return AttrOf("max", max) }
+func MinN(min float64) Attribute         { return AttrOf("min", min) }
+func StepN(step float64) Attribute       { return AttrOf("step", step) }
+func HighN(high float64) Attribute       { return AttrOf("high", high) }
+func LowN(low float64) Attribute         { return AttrOf("low", low) }
+func OptimumN(optimum float64) Attribute { return AttrOf("optimum", optimum) }
+func DateTimeT(t time.Time) Attribute    { return AttrOf("datetime", t) }
+
+func HiddenIf(hidden bool) BoolAttribute       { return BoolAttr("hidden", hidden) }
+func AutoFocusIf(autoFocus bool) BoolAttribute { return BoolAttr("autofocus", autoFocus) }
+func MultipleIf(multiple bool) BoolAttribute   { return BoolAttr("multiple", multiple) }
+func IsMapIf(isMap bool) BoolAttribute         { return BoolAttr("ismap", isMap) }
+func DisabledIf(disabled bool) BoolAttribute   { return BoolAttr("disabled", disabled) }
+func CheckedIf(checked bool) BoolAttribute     { return BoolAttr("checked", checked) }
+func ReadOnlyIf(readOnly bool) BoolAttribute   { return BoolAttr("readonly", readOnly) }
+func RequiredIf(required bool) BoolAttribute   { return BoolAttr("required", required) }
+func NoValidateIf(noValidate bool) BoolAttribute {
+	return BoolAttr("novalidate", noValidate)
+}
+func FormNoValidateIf(formNoValidate bool) BoolAttribute {
+	return BoolAttr("formnovalidate", formNoValidate)
+}
@@ -0,0 +1,98 @@
+package yahw
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type level int
+
+type color struct{ r, g, b uint8 }
+
+func (c color) String() string { return fmt.Sprintf("#%02x%02x%02x", c.r, c.g, c.b) }
+
+func TestAttrOf(t *testing.T) {
+	tt := []struct {
+		Name string
+		Attr Attribute
+		Exp  string
+	}{
+		{Name: "String", Attr: AttrOf("a", "x"), Exp: `a="x"`},
+		{Name: "Int", Attr: AttrOf("a", -12), Exp: `a="-12"`},
+		{Name: "Named int", Attr: AttrOf("a", level(3)), Exp: `a="3"`},
+		{Name: "Uint", Attr: AttrOf("a", uint8(255)), Exp: `a="255"`},
+		{Name: "Float", Attr: AttrOf("a", 0.1), Exp: `a="0.1"`},
+		{Name: "Large float", Attr: AttrOf("a", 1e21), Exp: `a="1000000000000000000000"`},
+		{Name: "Float32", Attr: AttrOf("a", float32(0.1)), Exp: `a="0.1"`},
+		{Name: "Bool", Attr: AttrOf("a", false), Exp: `a="false"`},
+		{Name: "Time", Attr: AttrOf("a", time.Date(2024, 5, 6, 7, 8, 9, 10, time.FixedZone("", 2*60*60))), Exp: `a="2024-05-06T07:08:09+02:00"`},
+		{Name: "Stringer", Attr: AttrOf("a", color{r: 0xf0, b: 0x0a}), Exp: `a="#f0000a"`},
+		{Name: "URL", Attr: AttrOf("a", &url.URL{Scheme: "https", Host: "x.com", Path: "/a b"}), Exp: `a="https://x.com/a%20b"`},
+		{Name: "Other", Attr: AttrOf("a", []int{1, 2}), Exp: `a="[1 2]"`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Attr, tc.Exp)
+		})
+	}
+
+	assertPanic(t, func() { AttrOf("a b", 1) })
+}
+
+func TestTypedAttributes(t *testing.T) {
+	assertEqual(t, Td(ColSpanN(2), RowSpanN(3)), `<td colspan="2" rowspan="3"></td>`)
+	assertEqual(t, Input(TabIndexN(-1), MaxN(1.5), StepN(0.25)), `<input tabindex="-1" max="1.5" step="0.25" />`)
+	assertEqual(t, Time(DateTimeT(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))), `<time datetime="2024-01-02T03:04:05Z"></time>`)
+}
+
+func TestBoolAttributes(t *testing.T) {
+	tt := []struct {
+		Name string
+		Node Renderable
+		Exp  string
+	}{
+		{Name: "Set", Node: Div(HiddenIf(true), ID("a")), Exp: `<div hidden id="a"></div>`},
+		{Name: "Not set", Node: Div(HiddenIf(false), ID("a")), Exp: `<div id="a"></div>`},
+		{Name: "Self-closing tag", Node: Input(DisabledIf(false), CheckedIf(true), RequiredIf(false)), Exp: `<input checked />`},
+		{Name: "Standalone", Node: AutoFocusIf(true), Exp: `autofocus`},
+		{Name: "Standalone not set", Node: AutoFocusIf(false), Exp: ``},
+		{Name: "Custom", Node: Details(BoolAttr("open", true)), Exp: `<details open></details>`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Node, tc.Exp)
+		})
+	}
+
+	assertPanic(t, func() { BoolAttr("a b", true) })
+}
+
+func TestConditionalAttributes(t *testing.T) {
+	title := "t"
+	tt := []struct {
+		Name string
+		Node Renderable
+		Exp  string
+	}{
+		{Name: "AttrIf true", Node: Div(AttrIf(true, ID("a")), Text("x")), Exp: `<div id="a">x</div>`},
+		{Name: "AttrIf false", Node: Div(AttrIf(false, ID("a")), Text("x")), Exp: `<div>x</div>`},
+		{Name: "AttrIf in self-closing tag", Node: Input(AttrIf(false, Disabled()), AttrIf(true, Name("n"))), Exp: `<input name="n" />`},
+		{Name: "AttrIf with classes", Node: Div(Classes("a"), AttrIf(true, Classes("b"))), Exp: `<div class="a b"></div>`},
+		{Name: "BoolAttr", Node: Input(BoolAttr("disabled", true), BoolAttr("readonly", false)), Exp: `<input disabled />`},
+		{Name: "OptionalAttr set", Node: Img(OptionalAttr("title", &title)), Exp: `<img title="t" />`},
+		{Name: "OptionalAttr nil", Node: Img(OptionalAttr("title", nil), Alt("")), Exp: `<img alt="" />`},
+		{Name: "IfElse in self-closing tag", Node: Input(If(false, ID("a")).Else(ID("b"))), Exp: `<input id="b" />`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Node, tc.Exp)
+		})
+	}
+
+	assertPanic(t, func() { OptionalAttr("a b", nil) })
+}
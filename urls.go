@@ -0,0 +1,169 @@
+package yahw
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// InvalidURL replaces URLs whose scheme isn't allowed.
+const InvalidURL = "about:invalid#yahw"
+
+// DefaultURLSchemes are the schemes allowed in URL attributes unless
+// overridden with WithURLSchemes. Relative URLs are always allowed.
+var DefaultURLSchemes = []string{"http", "https", "mailto", "tel"}
+
+// TrustedURL is rendered as is, without checking its scheme. Only use it for
+// URLs that don't contain user input.
+type TrustedURL string
+
+type urlSchemesKey struct{}
+
+// WithURLSchemes returns a context which allows only the given schemes in URL
+// attributes rendered with it.
+func WithURLSchemes(ctx context.Context, schemes ...string) context.Context {
+	lowered := make([]string, len(schemes))
+	for i, s := range schemes {
+		lowered[i] = strings.ToLower(s)
+	}
+	return context.WithValue(ctx, urlSchemesKey{}, lowered)
+}
+
+func allowedURLSchemes(ctx context.Context) []string {
+	if schemes, ok := ctx.Value(urlSchemesKey{}).([]string); ok {
+		return schemes
+	}
+	return DefaultURLSchemes
+}
+
+type urlKind int
+
+const (
+	singleURL urlKind = iota
+	// urlList is a space separated list of URLs, as in ping.
+	urlList
+	// srcSetURL is a comma separated list of image candidates.
+	srcSetURL
+)
+
+// urlAttrs are attributes whose values are sanitized even when they are
+// created with BuildAttr.
+var urlAttrs = map[string]urlKind{
+	"action":     singleURL,
+	"background": singleURL,
+	"cite":       singleURL,
+	"codebase":   singleURL,
+	"data":       singleURL,
+	"formaction": singleURL,
+	"href":       singleURL,
+	"longdesc":   singleURL,
+	"manifest":   singleURL,
+	"ping":       urlList,
+	"poster":     singleURL,
+	"src":        singleURL,
+	"srcset":     srcSetURL,
+	"xlink:href": singleURL,
+}
+
+func isURLAttr(key string) bool {
+	_, ok := urlAttrs[strings.ToLower(key)]
+	return ok
+}
+
+func urlScheme(u string) (string, bool) {
+	// Browsers ignore these anywhere in the URL, so "java\tscript:" is
+	// still a javascript URL.
+	u = strings.Map(func(r rune) rune {
+		switch r {
+		case '\t', '\n', '\r':
+			return -1
+		}
+		return r
+	}, u)
+	u = strings.TrimLeft(u, "\x00\x01\x02\x03\x04\x05\x06\x07\x08\x0b\x0c\x0e\x0f\x10\x11\x12\x13\x14\x15\x16\x17\x18\x19\x1a\x1b\x1c\x1d\x1e\x1f ")
+
+	end := strings.IndexAny(u, ":/?#")
+	if end < 0 || u[end] != ':' {
+		return "", false
+	}
+	return strings.ToLower(u[:end]), true
+}
+
+func sanitizeURL(ctx context.Context, u string) string {
+	scheme, ok := urlScheme(u)
+	if !ok {
+		return u // relative
+	}
+	for _, allowed := range allowedURLSchemes(ctx) {
+		if scheme == allowed {
+			return u
+		}
+	}
+	return InvalidURL
+}
+
+func sanitizeURLList(ctx context.Context, list string) string {
+	urls := strings.Fields(list)
+	for i, u := range urls {
+		urls[i] = sanitizeURL(ctx, u)
+	}
+	return strings.Join(urls, " ")
+}
+
+func sanitizeSrcSet(ctx context.Context, srcset string) string {
+	candidates := strings.Split(srcset, ",")
+	for i, c := range candidates {
+		fields := strings.Fields(c)
+		if len(fields) == 0 {
+			continue
+		}
+		fields[0] = sanitizeURL(ctx, fields[0])
+		candidates[i] = strings.Join(fields, " ")
+	}
+	return strings.Join(candidates, ", ")
+}
+
+func sanitizeURLAttr(ctx context.Context, key, value string) string {
+	switch urlAttrs[strings.ToLower(key)] {
+	case urlList:
+		return sanitizeURLList(ctx, value)
+	case srcSetURL:
+		return sanitizeSrcSet(ctx, value)
+	default:
+		return sanitizeURL(ctx, value)
+	}
+}
+
+// URLAttribute is an attribute holding one or more URLs. Unless it was created
+// from a TrustedURL, URLs with schemes that aren't allowed are replaced with
+// InvalidURL when rendering.
+type URLAttribute struct {
+	key     string
+	value   string
+	trusted bool
+}
+
+func (a URLAttribute) attr()                               {}
+func (a URLAttribute) Node(ctx context.Context) Renderable { return a }
+
+func (a URLAttribute) Render(ctx context.Context, w io.Writer) error {
+	value := a.value
+	if !a.trusted {
+		value = sanitizeURLAttr(ctx, a.key, value)
+	}
+	return writeAttr(ctx, w, a.key, value)
+}
+
+// BuildURLAttr creates an attribute holding URLs. See URLAttribute.
+func BuildURLAttr[T string | TrustedURL](key string, value T) URLAttribute {
+	if !isValidAttrName(key) {
+		panic(fmt.Errorf("%w: %q", ErrInvalidAttrName, key))
+	}
+	_, trusted := any(value).(TrustedURL)
+	return URLAttribute{
+		key:     key,
+		value:   string(value),
+		trusted: trusted,
+	}
+}
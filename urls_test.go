@@ -0,0 +1,67 @@
+package yahw
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestURLSanitization(t *testing.T) {
+	tt := []struct {
+		Name string
+		Attr Renderable
+		Exp  string
+	}{
+		{Name: "Absolute", Attr: Href("https://example.com/a?b=c&d"), Exp: `href="https://example.com/a?b=c&amp;d"`},
+		{Name: "Relative", Attr: Href("/foo/bar"), Exp: `href="/foo/bar"`},
+		{Name: "Relative with colon in path", Attr: Href("/foo:bar"), Exp: `href="/foo:bar"`},
+		{Name: "Fragment", Attr: Href("#top"), Exp: `href="#top"`},
+		{Name: "Mailto", Attr: Href("mailto:me@example.com"), Exp: `href="mailto:me@example.com"`},
+		{Name: "Javascript", Attr: Href("javascript:alert(1)"), Exp: `href="about:invalid#yahw"`},
+		{Name: "Javascript uppercase", Attr: Href("JavaScript:alert(1)"), Exp: `href="about:invalid#yahw"`},
+		{Name: "Javascript with whitespace", Attr: Href(" java\tscript:alert(1)"), Exp: `href="about:invalid#yahw"`},
+		{Name: "Data", Attr: Src("data:text/html,<b>"), Exp: `src="about:invalid#yahw"`},
+		{Name: "Action", Attr: Action("vbscript:x"), Exp: `action="about:invalid#yahw"`},
+		{Name: "FormAction", Attr: FormAction("/submit"), Exp: `formaction="/submit"`},
+		{Name: "Ping", Attr: Ping("/a javascript:b https://c"), Exp: `ping="/a about:invalid#yahw https://c"`},
+		{Name: "SrcSet", Attr: SrcSet("a.png 1x, javascript:b 2x"), Exp: `srcset="a.png 1x, about:invalid#yahw 2x"`},
+		{Name: "Trusted", Attr: Href(TrustedURL("javascript:alert(1)")), Exp: `href="javascript:alert(1)"`},
+		{Name: "BuildAttr", Attr: BuildAttr("href", "javascript:alert(1)"), Exp: `href="about:invalid#yahw"`},
+		{Name: "Object data", Attr: Object(BuildAttr("data", "javascript:alert(1)")), Exp: `<object data="about:invalid#yahw"></object>`},
+		{Name: "Object data relative", Attr: Object(BuildAttr("data", "/movie.swf")), Exp: `<object data="/movie.swf"></object>`},
+		{Name: "Non URL attribute", Attr: TitleAttr("javascript:alert(1)"), Exp: `title="javascript:alert(1)"`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			assertEqual(t, tc.Attr, tc.Exp)
+		})
+	}
+}
+
+func TestCustomURLSchemes(t *testing.T) {
+	ctx := WithURLSchemes(context.Background(), "HTTPS", "data")
+
+	tt := []struct {
+		Name string
+		Attr Renderable
+		Exp  string
+	}{
+		{Name: "Allowed", Attr: Src("data:image/png;base64,AAAA"), Exp: `src="data:image/png;base64,AAAA"`},
+		{Name: "Allowed uppercase", Attr: Href("HTTPS://example.com"), Exp: `href="HTTPS://example.com"`},
+		{Name: "No longer allowed", Attr: Href("http://example.com"), Exp: `href="about:invalid#yahw"`},
+		{Name: "Relative", Attr: Href("foo"), Exp: `href="foo"`},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.Name, func(t *testing.T) {
+			strbuf := &strings.Builder{}
+			if err := tc.Attr.Render(ctx, strbuf); err != nil {
+				t.Fatalf("Error rendering: %s", err)
+			}
+			if strbuf.String() != tc.Exp {
+				t.Errorf("Expected %s, got %s", tc.Exp, strbuf.String())
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package yahw_test
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -20,7 +21,7 @@ func TestSomewhatRealworldExample(t *testing.T) {
 	)
 
 	strbuf := &strings.Builder{}
-	err := root.Render(strbuf)
+	err := root.Render(context.Background(), strbuf)
 	if err != nil {
 		t.Errorf("Error rendering: %s", err)
 	}